@@ -0,0 +1,223 @@
+package softlayer
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Cloud Object Storage buckets are managed through the S3 API of the Cloud Object Storage endpoints, which
+// is not part of the SoftLayer API. Requests are signed with AWS Signature Version 4 using the HMAC keys of
+// a softlayer_cos_credential.
+
+const (
+	// The S3 endpoint can be replaced, e.g. with a local S3-compatible storage for testing.
+	CosEndpointEnv = "SOFTLAYER_COS_ENDPOINT"
+
+	// Cloud Object Storage accepts any signing region, the location of a bucket is set by its location
+	// constraint.
+	cosSigningRegion = "us-standard"
+)
+
+// cosHttpClient sends the S3 requests, which time out instead of hanging on an unreachable endpoint.
+var cosHttpClient = &http.Client{
+	Timeout: 60 * time.Second,
+}
+
+// cosEndpoints are the public S3 endpoints of the Cloud Object Storage regions.
+var cosEndpoints = map[string]string{
+	"us":       "https://s3-api.us-geo.objectstorage.softlayer.net",
+	"eu":       "https://s3.eu-geo.objectstorage.softlayer.net",
+	"us-south": "https://s3.us-south.objectstorage.softlayer.net",
+	"us-east":  "https://s3.us-east.objectstorage.softlayer.net",
+}
+
+type s3Client struct {
+	endpoint  string
+	accessKey string
+	secretKey string
+}
+
+// getCosEndpoint returns the S3 endpoint of the given region.
+func getCosEndpoint(region string) (string, error) {
+	if endpoint := os.Getenv(CosEndpointEnv); endpoint != "" {
+		return strings.TrimSuffix(endpoint, "/"), nil
+	}
+
+	endpoint, ok := cosEndpoints[region]
+	if !ok {
+		return "", fmt.Errorf("No Cloud Object Storage endpoint known for region %s", region)
+	}
+
+	return endpoint, nil
+}
+
+// s3Error is returned for S3 error responses. The code is the S3 error code, e.g. NoSuchBucket.
+type s3Error struct {
+	StatusCode int    `xml:"-"`
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+}
+
+func (e s3Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func isS3NotFound(err error) bool {
+	s3Err, ok := err.(s3Error)
+	return ok && s3Err.StatusCode == http.StatusNotFound
+}
+
+// Do sends a signed request for a bucket and returns the response body. The query is a raw query string,
+// e.g. "location".
+func (c *s3Client) Do(method string, bucket string, query string, body []byte) ([]byte, error) {
+	if body == nil {
+		body = []byte{}
+	}
+
+	reqURL := c.endpoint + "/" + url.QueryEscape(bucket)
+	if query != "" {
+		reqURL += "?" + query
+	}
+
+	req, err := http.NewRequest(method, reqURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	signS3Request(req, body, c.accessKey, c.secretKey, cosSigningRegion, time.Now())
+
+	log.Printf("[DEBUG] Cloud Object Storage request: %s %s", method, req.URL.String())
+
+	resp, err := cosHttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		s3Err := s3Error{}
+		xml.Unmarshal(respBody, &s3Err)
+		s3Err.StatusCode = resp.StatusCode
+		return nil, s3Err
+	}
+
+	return respBody, nil
+}
+
+// signS3Request adds the AWS Signature Version 4 authorization to a request. The host and all headers set
+// on the request are signed.
+func signS3Request(req *http.Request, body []byte, accessKey string, secretKey string, region string, now time.Time) {
+	now = now.UTC()
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", now.Format("20060102T150405Z"))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(req.Header.Get(name))
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders bytes.Buffer
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		getS3CanonicalPath(req.URL),
+		getS3CanonicalQuery(req.URL),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		now.Format("20060102T150405Z"),
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s,SignedHeaders=%s,Signature=%s",
+		accessKey, scope, signedHeaders, signature))
+}
+
+func getS3CanonicalPath(u *url.URL) string {
+	path := u.EscapedPath()
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// getS3CanonicalQuery returns the query parameters sorted by name. Parameters without a value, like
+// ?location, have an empty value.
+func getS3CanonicalQuery(u *url.URL) string {
+	query := u.Query()
+
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	params := make([]string, 0, len(names))
+	for _, name := range names {
+		for _, value := range query[name] {
+			params = append(params, s3Escape(name)+"="+s3Escape(value))
+		}
+	}
+
+	return strings.Join(params, "&")
+}
+
+func s3Escape(s string) string {
+	return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
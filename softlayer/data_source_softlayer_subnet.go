@@ -0,0 +1,176 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const SubnetMask = "id,networkIdentifier,cidr,subnetType,version,gateway,broadcastAddress,netmask,networkVlanId," +
+	"ipAddresses[id,ipAddress,isNetwork,isGateway,isBroadcast,isReserved,note," +
+	"guestNetworkComponentBinding[id],hardware[id],applicationDeliveryController[id]]"
+
+func dataSourceSoftLayerSubnet() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSoftLayerSubnetRead,
+
+		Schema: map[string]*schema.Schema{
+			"cidr": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"network_identifier": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"subnet_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"gateway": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"broadcast_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"netmask": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"vlan_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"ip_addresses": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"is_network": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"is_gateway": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"is_broadcast": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"is_reserved": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"in_use": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"note": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"next_available_ips": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceSoftLayerSubnetRead(d *schema.ResourceData, meta interface{}) error {
+	subnet, err := getSubnet(d.Get("cidr").(string), SubnetMask, meta)
+	if err != nil {
+		return fmt.Errorf("Error retrieving subnet: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(*subnet.Id))
+	d.Set("network_identifier", sl.Get(subnet.NetworkIdentifier, ""))
+	d.Set("subnet_type", sl.Get(subnet.SubnetType, ""))
+	d.Set("version", sl.Get(subnet.Version, 4))
+	d.Set("gateway", sl.Get(subnet.Gateway, ""))
+	d.Set("broadcast_address", sl.Get(subnet.BroadcastAddress, ""))
+	d.Set("netmask", sl.Get(subnet.Netmask, ""))
+	d.Set("vlan_id", sl.Get(subnet.NetworkVlanId, 0))
+
+	ipAddresses := make([]map[string]interface{}, 0, len(subnet.IpAddresses))
+	nextAvailableIps := make([]string, 0)
+
+	for _, ip := range subnet.IpAddresses {
+		inUse := isIpAddressInUse(ip)
+
+		ipAddresses = append(ipAddresses, map[string]interface{}{
+			"id":           *ip.Id,
+			"ip_address":   *ip.IpAddress,
+			"is_network":   sl.Get(ip.IsNetwork, false),
+			"is_gateway":   sl.Get(ip.IsGateway, false),
+			"is_broadcast": sl.Get(ip.IsBroadcast, false),
+			"is_reserved":  sl.Get(ip.IsReserved, false),
+			"in_use":       inUse,
+			"note":         sl.Get(ip.Note, ""),
+		})
+
+		if !inUse {
+			nextAvailableIps = append(nextAvailableIps, *ip.IpAddress)
+		}
+	}
+
+	d.Set("ip_addresses", ipAddresses)
+	d.Set("next_available_ips", nextAvailableIps)
+
+	return nil
+}
+
+// isIpAddressInUse returns true if the IP address is a network, gateway, broadcast or reserved
+// address, or if it is bound to a virtual guest, hardware or application delivery controller.
+// An IP address which only has a note is treated as claimed as well, so that addresses picked
+// for VIPs can be marked by their note.
+func isIpAddressInUse(ip datatypes.Network_Subnet_IpAddress) bool {
+	if sl.Get(ip.IsNetwork, false).(bool) || sl.Get(ip.IsGateway, false).(bool) ||
+		sl.Get(ip.IsBroadcast, false).(bool) || sl.Get(ip.IsReserved, false).(bool) {
+		return true
+	}
+
+	if ip.GuestNetworkComponentBinding != nil || ip.Hardware != nil || ip.ApplicationDeliveryController != nil {
+		return true
+	}
+
+	return sl.Get(ip.Note, "").(string) != ""
+}
@@ -0,0 +1,47 @@
+package softlayer
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSoftLayerSubnetDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerSubnetDataSourceConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"data.softlayer_subnet.tfuat_subnet", "network_identifier"),
+					resource.TestCheckResourceAttr(
+						"data.softlayer_subnet.tfuat_subnet", "version", "4"),
+					resource.TestCheckResourceAttrSet(
+						"data.softlayer_subnet.tfuat_subnet", "gateway"),
+					resource.TestCheckResourceAttrSet(
+						"data.softlayer_subnet.tfuat_subnet", "ip_addresses.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckSoftLayerSubnetDataSourceConfig_basic = `
+resource "softlayer_virtual_guest" "tfuat_subnet_guest" {
+    name = "terraform-subnet-test"
+    domain = "bar.example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "ams01"
+    network_speed = 10
+    hourly_billing = true
+    cpu = 1
+    ram = 1024
+    local_disk = false
+}
+
+data "softlayer_subnet" "tfuat_subnet" {
+    cidr = "${softlayer_virtual_guest.tfuat_subnet_guest.front_end_subnet}"
+}
+`
@@ -0,0 +1,666 @@
+package softlayer
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+// The records of a DNS domain can be imported from a BIND zone file. The zone file is parsed into resource
+// records, which are compared with the records of the domain, so only the differences are applied.
+
+const (
+	dnsZoneRecordMask = "id,domainId,host,type,data,ttl,mxPriority,service,protocol,port,priority,weight"
+
+	// The TTL of records without a TTL, if the zone file has no $TTL directive.
+	dnsZoneDefaultTtl = 86400
+)
+
+// dnsZoneNameTypes are the record types whose data is a domain name.
+var dnsZoneNameTypes = map[string]bool{
+	"cname": true,
+	"mx":    true,
+	"ns":    true,
+	"ptr":   true,
+	"srv":   true,
+}
+
+// isDnsZoneManagedRecord returns false for the SOA and NS records of the domain itself, which SoftLayer
+// creates with the domain. These records are neither imported nor deleted.
+func isDnsZoneManagedRecord(record datatypes.Dns_Domain_ResourceRecord) bool {
+	recordType := strings.ToLower(sl.Get(record.Type, "").(string))
+	return recordType != "soa" && !(recordType == "ns" && sl.Get(record.Host, "") == "@")
+}
+
+// parseDnsZoneFile parses the records of a BIND zone file for the given domain, e.g. "example.com". Hosts are
+// relative to the domain, e.g. "www" or "@", and domain names in record data are fully qualified.
+func parseDnsZoneFile(zoneFile string, domain string) ([]datatypes.Dns_Domain_ResourceRecord, error) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	origin := domain + "."
+	defaultTtl := dnsZoneDefaultTtl
+	lastOwner := "@"
+
+	records := []datatypes.Dns_Domain_ResourceRecord{}
+
+	lines, err := joinDnsZoneLines(zoneFile)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, line := range lines {
+		fields, err := splitDnsZoneLine(line.text)
+		if err != nil {
+			return nil, fmt.Errorf("Line %d: %s", line.number, err)
+		}
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch strings.ToUpper(fields[0]) {
+		case "$ORIGIN":
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("Line %d: $ORIGIN requires a domain name", line.number)
+			}
+			origin = qualifyDnsZoneName(fields[1], origin)
+			continue
+		case "$TTL":
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("Line %d: $TTL requires a TTL", line.number)
+			}
+			defaultTtl, err = parseDnsZoneTtl(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("Line %d: %s", line.number, err)
+			}
+			continue
+		case "$INCLUDE", "$GENERATE":
+			return nil, fmt.Errorf("Line %d: %s is not supported", line.number, fields[0])
+		}
+
+		// A record without an owner belongs to the owner of the previous record.
+		owner := lastOwner
+		if !line.continued {
+			owner, err = relativeDnsZoneHost(qualifyDnsZoneName(fields[0], origin), domain)
+			if err != nil {
+				return nil, fmt.Errorf("Line %d: %s", line.number, err)
+			}
+			fields = fields[1:]
+		}
+		lastOwner = owner
+
+		ttl := defaultTtl
+		for len(fields) > 0 {
+			if strings.ToUpper(fields[0]) == "IN" {
+				fields = fields[1:]
+			} else if fieldTtl, err := parseDnsZoneTtl(fields[0]); err == nil {
+				ttl = fieldTtl
+				fields = fields[1:]
+			} else {
+				break
+			}
+		}
+
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("Line %d: missing record type or data", line.number)
+		}
+
+		record, err := parseDnsZoneRecord(strings.ToLower(fields[0]), fields[1:], owner, origin, line.quoted)
+		if err != nil {
+			return nil, fmt.Errorf("Line %d: %s", line.number, err)
+		}
+
+		// The SOA record is managed by SoftLayer.
+		if *record.Type == "soa" {
+			continue
+		}
+
+		record.Ttl = sl.Int(ttl)
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// parseDnsZoneRecord parses the type and data of a record.
+func parseDnsZoneRecord(recordType string, data []string, host string, origin string,
+	quoted bool) (datatypes.Dns_Domain_ResourceRecord, error) {
+	record := datatypes.Dns_Domain_ResourceRecord{
+		Host: sl.String(host),
+		Type: sl.String(recordType),
+	}
+
+	expectFields := func(count int) error {
+		if len(data) != count {
+			return fmt.Errorf("%s record requires %d data fields, got %d", strings.ToUpper(recordType), count, len(data))
+		}
+		return nil
+	}
+
+	switch recordType {
+	case "a", "aaaa":
+		if err := expectFields(1); err != nil {
+			return record, err
+		}
+		record.Data = sl.String(strings.ToLower(data[0]))
+	case "cname", "ns", "ptr":
+		if err := expectFields(1); err != nil {
+			return record, err
+		}
+		record.Data = sl.String(qualifyDnsZoneName(data[0], origin))
+	case "mx":
+		if err := expectFields(2); err != nil {
+			return record, err
+		}
+		priority, err := strconv.Atoi(data[0])
+		if err != nil {
+			return record, fmt.Errorf("Invalid MX priority: %s", data[0])
+		}
+		record.MxPriority = sl.Int(priority)
+		record.Data = sl.String(qualifyDnsZoneName(data[1], origin))
+	case "srv":
+		if err := expectFields(4); err != nil {
+			return record, err
+		}
+
+		// The host of a SRV record is prefixed with the service and the protocol, e.g. _sip._tcp.www.
+		labels := strings.SplitN(host, ".", 3)
+		if len(labels) < 2 || !strings.HasPrefix(labels[0], "_") || !strings.HasPrefix(labels[1], "_") {
+			return record, fmt.Errorf("SRV record host must start with _service._protocol: %s", host)
+		}
+		record.Service = sl.String(labels[0])
+		record.Protocol = sl.String(labels[1])
+		record.Host = sl.String("@")
+		if len(labels) == 3 {
+			record.Host = sl.String(labels[2])
+		}
+
+		numbers := make([]int, 3)
+		for i, field := range data[:3] {
+			number, err := strconv.Atoi(field)
+			if err != nil {
+				return record, fmt.Errorf("Invalid SRV priority, weight or port: %s", field)
+			}
+			numbers[i] = number
+		}
+		record.Priority = sl.Int(numbers[0])
+		record.Weight = sl.Int(numbers[1])
+		record.Port = sl.Int(numbers[2])
+		record.Data = sl.String(qualifyDnsZoneName(data[3], origin))
+	case "txt", "spf":
+		// The character strings of a record are concatenated, e.g. a DKIM key split into several strings.
+		if quoted {
+			record.Data = sl.String(strings.Join(data, ""))
+		} else {
+			record.Data = sl.String(strings.Join(data, " "))
+		}
+	case "caa":
+		if err := expectFields(3); err != nil {
+			return record, err
+		}
+		record.Data = sl.String(fmt.Sprintf(`%s %s "%s"`, data[0], strings.ToLower(data[1]), data[2]))
+		if err := validateCaaData(*record.Data); err != nil {
+			return record, err
+		}
+	case "soa":
+	default:
+		return record, fmt.Errorf("%s records are not supported", strings.ToUpper(recordType))
+	}
+
+	return record, nil
+}
+
+// dnsZoneLine is a record or directive of a zone file, with its parentheses joined into one line.
+type dnsZoneLine struct {
+	number    int
+	text      string
+	continued bool
+	quoted    bool
+}
+
+// joinDnsZoneLines removes comments and joins records which are split over several lines by parentheses.
+func joinDnsZoneLines(zoneFile string) ([]dnsZoneLine, error) {
+	lines := []dnsZoneLine{}
+
+	var current *dnsZoneLine
+	depth := 0
+
+	scanner := bufio.NewScanner(strings.NewReader(zoneFile))
+	for number := 1; scanner.Scan(); number++ {
+		text := scanner.Text()
+
+		var stripped []rune
+		inQuotes, escaped := false, false
+	scan:
+		for _, c := range text {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inQuotes = !inQuotes
+			case inQuotes:
+			case c == ';':
+				break scan
+			case c == '(':
+				depth++
+				c = ' '
+			case c == ')':
+				depth--
+				c = ' '
+			}
+
+			stripped = append(stripped, c)
+		}
+
+		if depth < 0 {
+			return nil, fmt.Errorf("Line %d: unbalanced parentheses", number)
+		}
+
+		if current == nil {
+			current = &dnsZoneLine{
+				number:    number,
+				continued: len(text) > 0 && (text[0] == ' ' || text[0] == '\t'),
+			}
+		}
+
+		current.text += " " + string(stripped)
+		current.quoted = current.quoted || strings.Contains(string(stripped), `"`)
+
+		if depth == 0 {
+			if strings.TrimSpace(current.text) != "" {
+				lines = append(lines, *current)
+			}
+			current = nil
+		}
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("Unbalanced parentheses at the end of the zone file")
+	}
+
+	return lines, scanner.Err()
+}
+
+// splitDnsZoneLine splits a line into fields. Quoted strings are single fields, without the quotes.
+func splitDnsZoneLine(line string) ([]string, error) {
+	fields := []string{}
+
+	var field []rune
+	inQuotes, inField := false, false
+	escaped := false
+
+	for _, c := range line {
+		switch {
+		case escaped:
+			field = append(field, c)
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			if inQuotes {
+				fields = append(fields, string(field))
+				field, inField = nil, false
+			}
+			inQuotes = !inQuotes
+		case inQuotes:
+			field = append(field, c)
+		case c == ' ' || c == '\t':
+			if inField {
+				fields = append(fields, string(field))
+				field, inField = nil, false
+			}
+		default:
+			field = append(field, c)
+			inField = true
+		}
+	}
+
+	if inQuotes {
+		return nil, fmt.Errorf("Unterminated quoted string")
+	}
+
+	if inField {
+		fields = append(fields, string(field))
+	}
+
+	return fields, nil
+}
+
+// parseDnsZoneTtl parses a TTL in seconds, or with BIND units, e.g. 1h30m.
+func parseDnsZoneTtl(value string) (int, error) {
+	if ttl, err := strconv.Atoi(value); err == nil && ttl >= 0 {
+		return ttl, nil
+	}
+
+	units := map[rune]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+
+	ttl, number := 0, ""
+	for _, c := range strings.ToLower(value) {
+		if c >= '0' && c <= '9' {
+			number += string(c)
+			continue
+		}
+
+		unit, ok := units[c]
+		if !ok || number == "" {
+			return 0, fmt.Errorf("Invalid TTL: %s", value)
+		}
+
+		n, _ := strconv.Atoi(number)
+		ttl += n * unit
+		number = ""
+	}
+
+	if number != "" || value == "" {
+		return 0, fmt.Errorf("Invalid TTL: %s", value)
+	}
+
+	return ttl, nil
+}
+
+// qualifyDnsZoneName returns the fully qualified name, with a trailing dot, of a name in a zone file.
+func qualifyDnsZoneName(name string, origin string) string {
+	name = strings.ToLower(name)
+	origin = strings.ToLower(origin)
+
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return name
+	default:
+		return name + "." + origin
+	}
+}
+
+// relativeDnsZoneHost returns the host of a fully qualified name within the domain, e.g. "www" or "@".
+func relativeDnsZoneHost(name string, domain string) (string, error) {
+	name = strings.TrimSuffix(name, ".")
+
+	switch {
+	case name == domain:
+		return "@", nil
+	case strings.HasSuffix(name, "."+domain):
+		return strings.TrimSuffix(name, "."+domain), nil
+	default:
+		return "", fmt.Errorf("%s is not in domain %s", name, domain)
+	}
+}
+
+// dnsZoneRecordKey identifies a record by its type, host and data. Records with the same key are the same
+// record, which may differ in TTL or priority.
+func dnsZoneRecordKey(record datatypes.Dns_Domain_ResourceRecord) string {
+	recordType := strings.ToLower(sl.Get(record.Type, "").(string))
+	data := sl.Get(record.Data, "").(string)
+
+	if dnsZoneNameTypes[recordType] {
+		data = strings.TrimSuffix(strings.ToLower(data), ".")
+	}
+
+	if recordType == "txt" {
+		data = joinDnsTxtData(data)
+	}
+
+	return strings.Join([]string{
+		recordType,
+		strings.ToLower(sl.Get(record.Host, "").(string)),
+		sl.Get(record.Service, "").(string),
+		sl.Get(record.Protocol, "").(string),
+		data,
+	}, " ")
+}
+
+// diffDnsZoneRecords compares the records of a domain with the records of a zone file. It returns the records
+// to create, the changed records with the IDs of the existing records, and the records to delete.
+func diffDnsZoneRecords(current []datatypes.Dns_Domain_ResourceRecord,
+	wanted []datatypes.Dns_Domain_ResourceRecord) (creates, updates, deletes []datatypes.Dns_Domain_ResourceRecord) {
+	creates = []datatypes.Dns_Domain_ResourceRecord{}
+	updates = []datatypes.Dns_Domain_ResourceRecord{}
+	deletes = []datatypes.Dns_Domain_ResourceRecord{}
+
+	wantedByKey := map[string]datatypes.Dns_Domain_ResourceRecord{}
+	for _, record := range wanted {
+		if isDnsZoneManagedRecord(record) {
+			wantedByKey[dnsZoneRecordKey(record)] = record
+		}
+	}
+
+	found := map[string]bool{}
+	for _, record := range current {
+		if !isDnsZoneManagedRecord(record) {
+			continue
+		}
+
+		key := dnsZoneRecordKey(record)
+		want, ok := wantedByKey[key]
+		if !ok || found[key] {
+			deletes = append(deletes, record)
+			continue
+		}
+		found[key] = true
+
+		if sl.Get(record.Ttl, 0) != sl.Get(want.Ttl, 0) ||
+			sl.Get(record.MxPriority, 0) != sl.Get(want.MxPriority, 0) ||
+			sl.Get(record.Priority, 0) != sl.Get(want.Priority, 0) ||
+			sl.Get(record.Weight, 0) != sl.Get(want.Weight, 0) ||
+			sl.Get(record.Port, 0) != sl.Get(want.Port, 0) {
+			want.Id = record.Id
+			want.DomainId = record.DomainId
+			updates = append(updates, want)
+		}
+	}
+
+	for _, record := range wanted {
+		key := dnsZoneRecordKey(record)
+		if _, ok := wantedByKey[key]; ok && !found[key] {
+			creates = append(creates, record)
+			found[key] = true
+		}
+	}
+
+	return creates, updates, deletes
+}
+
+// ownedDnsZoneRecords returns the records of a domain which belong to its zone file: the records which were
+// imported from the zone file before, the target record, and records which match a record of the zone file
+// and aren't owned yet. Other records, e.g. of softlayer_dns_domain_record or softlayer_dns_record_set, are
+// left alone.
+func ownedDnsZoneRecords(current []datatypes.Dns_Domain_ResourceRecord, wanted []datatypes.Dns_Domain_ResourceRecord,
+	owned map[int]bool, target string) []datatypes.Dns_Domain_ResourceRecord {
+	records := []datatypes.Dns_Domain_ResourceRecord{}
+	ownedKeys := map[string]bool{}
+
+	for _, record := range current {
+		if !isDnsZoneManagedRecord(record) {
+			continue
+		}
+
+		if owned[*record.Id] || (*record.Type == "a" && *record.Host == "@" && *record.Data == target) {
+			records = append(records, record)
+			ownedKeys[dnsZoneRecordKey(record)] = true
+		}
+	}
+
+	wantedKeys := map[string]bool{}
+	for _, record := range wanted {
+		wantedKeys[dnsZoneRecordKey(record)] = true
+	}
+
+	// A record which exists already is taken over once, instead of creating a duplicate.
+	for _, record := range current {
+		key := dnsZoneRecordKey(record)
+		if isDnsZoneManagedRecord(record) && wantedKeys[key] && !ownedKeys[key] {
+			records = append(records, record)
+			ownedKeys[key] = true
+		}
+	}
+
+	return records
+}
+
+// getDnsZoneFileRecords returns the records of a domain which belong to its zone file, and the records of the
+// zone file. The target record of the domain is kept, unless the zone file has another A record for the domain
+// itself.
+func getDnsZoneFileRecords(sess *session.Session, domainId int, domain string, target string, zoneFile string,
+	owned map[int]bool) (current, wanted []datatypes.Dns_Domain_ResourceRecord, err error) {
+	wanted, err = parseDnsZoneFile(zoneFile, domain)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error parsing zone file of Dns Domain %d: %s", domainId, err)
+	}
+
+	records, err := services.GetDnsDomainService(sess).Id(domainId).Mask(dnsZoneRecordMask).GetResourceRecords()
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error retrieving records of Dns Domain %d: %s", domainId, err)
+	}
+
+	hasTarget := false
+	for _, record := range wanted {
+		if *record.Type == "a" && *record.Host == "@" {
+			hasTarget = true
+		}
+	}
+
+	if !hasTarget {
+		targetRecord := datatypes.Dns_Domain_ResourceRecord{
+			Data: sl.String(target),
+			Host: sl.String("@"),
+			Ttl:  sl.Int(dnsZoneDefaultTtl),
+			Type: sl.String("a"),
+		}
+
+		// The target record keeps its TTL, since the target argument has no TTL.
+		for _, record := range records {
+			if dnsZoneRecordKey(record) == dnsZoneRecordKey(targetRecord) {
+				targetRecord.Ttl = record.Ttl
+			}
+		}
+
+		wanted = append(wanted, targetRecord)
+	}
+
+	return ownedDnsZoneRecords(records, wanted, owned, target), wanted, nil
+}
+
+// diffDnsZoneFile returns the records which are created, edited and deleted to make the records of a domain
+// which belong to its zone file match the zone file.
+func diffDnsZoneFile(sess *session.Session, domainId int, domain string, target string, zoneFile string,
+	owned map[int]bool) (creates, updates, deletes []datatypes.Dns_Domain_ResourceRecord, err error) {
+	current, wanted, err := getDnsZoneFileRecords(sess, domainId, domain, target, zoneFile, owned)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	creates, updates, deletes = diffDnsZoneRecords(current, wanted)
+	return creates, updates, deletes, nil
+}
+
+// importDnsZoneFile makes the records of a domain which belong to its zone file match the zone file. It returns
+// the IDs of the records which belong to the zone file afterwards.
+func importDnsZoneFile(sess *session.Session, domainId int, domain string, target string, zoneFile string,
+	owned map[int]bool) ([]int, error) {
+	creates, updates, deletes, err := diffDnsZoneFile(sess, domainId, domain, target, zoneFile, owned)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[INFO] Importing zone file of Dns Domain %s: %d new, %d changed and %d deleted records",
+		domain, len(creates), len(updates), len(deletes))
+
+	err = applyDnsRecordChanges(sess, domainId, creates, updates, deletes)
+	if err != nil {
+		return nil, err
+	}
+
+	// The new records are found by matching them with the zone file, since records are created in batches.
+	current, _, err := getDnsZoneFileRecords(sess, domainId, domain, target, zoneFile, owned)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(current))
+	for _, record := range current {
+		ids = append(ids, *record.Id)
+	}
+
+	return ids, nil
+}
+
+// applyDnsRecordChanges deletes, edits and creates records of a domain. Records are batched, so a change of
+// many records takes a few API calls.
+func applyDnsRecordChanges(sess *session.Session, domainId int,
+	creates, updates, deletes []datatypes.Dns_Domain_ResourceRecord) error {
+	var err error
+
+	service := services.GetDnsDomainResourceRecordService(sess)
+	srvService := services.GetDnsDomainResourceRecordSrvTypeService(sess)
+
+	if len(deletes) > 0 {
+		_, err = service.DeleteObjects(deletes)
+		if err != nil {
+			return fmt.Errorf("Error deleting records of Dns Domain %d: %s", domainId, err)
+		}
+	}
+
+	// SRV records are created and edited through their own service, one by one.
+	records := []datatypes.Dns_Domain_ResourceRecord{}
+	for _, record := range updates {
+		if *record.Type == "txt" {
+			record.Data = sl.String(splitDnsTxtData(*record.Data))
+		}
+
+		if *record.Type == "srv" {
+			_, err = srvService.Id(*record.Id).EditObject(&datatypes.Dns_Domain_ResourceRecord_SrvType{
+				Dns_Domain_ResourceRecord: record,
+			})
+			if err != nil {
+				return fmt.Errorf("Error editing SRV record %d of Dns Domain %d: %s", *record.Id, domainId, err)
+			}
+			continue
+		}
+		records = append(records, record)
+	}
+
+	if len(records) > 0 {
+		_, err = service.EditObjects(records)
+		if err != nil {
+			return fmt.Errorf("Error editing records of Dns Domain %d: %s", domainId, err)
+		}
+	}
+
+	records = []datatypes.Dns_Domain_ResourceRecord{}
+	for _, record := range creates {
+		record.DomainId = sl.Int(domainId)
+
+		if *record.Type == "txt" {
+			record.Data = sl.String(splitDnsTxtData(*record.Data))
+		}
+
+		if *record.Type == "srv" {
+			_, err = srvService.CreateObject(&datatypes.Dns_Domain_ResourceRecord_SrvType{
+				Dns_Domain_ResourceRecord: record,
+			})
+			if err != nil {
+				return fmt.Errorf("Error creating SRV record of Dns Domain %d: %s", domainId, err)
+			}
+			continue
+		}
+		records = append(records, record)
+	}
+
+	if len(records) > 0 {
+		_, err = service.CreateObjects(records)
+		if err != nil {
+			return fmt.Errorf("Error creating records of Dns Domain %d: %s", domainId, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package softlayer
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const testDnsZoneFile = `
+$ORIGIN example.com.
+$TTL 1h
+@	IN	SOA	ns1.softlayer.com. root.example.com. (
+		2016120101 ; serial
+		7200       ; refresh
+		600        ; retry
+		1728000    ; expire
+		43200 )    ; minimum
+@		IN	NS	ns1.softlayer.com.
+@	300	IN	A	10.0.0.1
+www		IN	CNAME	@
+mail		IN	A	10.0.0.2
+		IN	AAAA	2001:DB8:0:0:0:0:0:1
+@		IN	MX	10 mail
+@		IN	TXT	"v=spf1 mx -all" ; SPF policy
+dkim._domainkey	IN	TXT	( "v=DKIM1; k=rsa; "
+			  "p=MIGfMA0GCSqGSIb3DQEB" )
+_sip._tcp	1d	IN	SRV	10 60 5060 sip.example.org.
+@		IN	CAA	0 ISSUE "letsencrypt.org"
+
+$ORIGIN dev.example.com.
+api		IN	A	10.0.1.1
+`
+
+func TestParseDnsZoneFile(t *testing.T) {
+	records, err := parseDnsZoneFile(testDnsZoneFile, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"ns @ 3600 ns1.softlayer.com.",
+		"a @ 300 10.0.0.1",
+		"cname www 3600 example.com.",
+		"a mail 3600 10.0.0.2",
+		"aaaa mail 3600 2001:db8:0:0:0:0:0:1",
+		"mx @ 3600 mail.example.com. mx=10",
+		"txt @ 3600 v=spf1 mx -all",
+		"txt dkim._domainkey 3600 v=DKIM1; k=rsa; p=MIGfMA0GCSqGSIb3DQEB",
+		"srv @ 86400 sip.example.org. srv=_sip._tcp 10 60 5060",
+		"caa @ 3600 0 issue \"letsencrypt.org\"",
+		"a api.dev 3600 10.0.1.1",
+	}
+
+	if len(records) != len(expected) {
+		t.Fatalf("Expected %d records, got %d: %v", len(expected), len(records), records)
+	}
+
+	for i, record := range records {
+		if formatted := testFormatDnsZoneRecord(record); formatted != expected[i] {
+			t.Errorf("Record %d: expected %q, got %q", i, expected[i], formatted)
+		}
+	}
+}
+
+func TestParseDnsZoneFileErrors(t *testing.T) {
+	cases := map[string]string{
+		"outside domain":   "www.example.org. IN A 10.0.0.1",
+		"unsupported type": "@ IN HINFO PC Linux",
+		"missing data":     "@ IN A",
+		"bad MX priority":  "@ IN MX high mail",
+		"bad SRV host":     "sip IN SRV 10 60 5060 sip.example.com.",
+		"open parentheses": "@ IN TXT ( \"text\"",
+		"open quote":       "@ IN TXT \"text",
+		"include":          "$INCLUDE other.zone",
+		"bad CAA tag":      "@ IN CAA 0 policy \"x\"",
+	}
+
+	for name, zoneFile := range cases {
+		if _, err := parseDnsZoneFile(zoneFile, "example.com"); err == nil {
+			t.Errorf("%s: expected an error for %q", name, zoneFile)
+		}
+	}
+}
+
+func TestParseDnsZoneTtl(t *testing.T) {
+	cases := map[string]int{
+		"300":   300,
+		"1h":    3600,
+		"1h30m": 5400,
+		"2D":    172800,
+		"1w":    604800,
+	}
+
+	for value, expected := range cases {
+		if ttl, err := parseDnsZoneTtl(value); err != nil || ttl != expected {
+			t.Errorf("parseDnsZoneTtl(%q): expected %d, got %d (%v)", value, expected, ttl, err)
+		}
+	}
+
+	for _, value := range []string{"", "A", "MX", "h1", "-1"} {
+		if _, err := parseDnsZoneTtl(value); err == nil {
+			t.Errorf("parseDnsZoneTtl(%q): expected an error", value)
+		}
+	}
+}
+
+func TestDiffDnsZoneRecords(t *testing.T) {
+	current := []datatypes.Dns_Domain_ResourceRecord{
+		testDnsZoneRecord(1, "soa", "@", "ns1.softlayer.com.", 86400),
+		testDnsZoneRecord(2, "ns", "@", "ns1.softlayer.com.", 86400),
+		testDnsZoneRecord(3, "a", "@", "10.0.0.1", 300),
+		testDnsZoneRecord(4, "cname", "www", "example.com", 3600),
+		testDnsZoneRecord(5, "a", "mail", "10.0.0.2", 3600),
+		testDnsZoneRecord(6, "a", "old", "10.0.0.3", 3600),
+		testDnsZoneRecord(7, "a", "mail", "10.0.0.2", 3600),
+	}
+
+	wanted := []datatypes.Dns_Domain_ResourceRecord{
+		testDnsZoneRecord(0, "a", "@", "10.0.0.1", 300),
+		testDnsZoneRecord(0, "cname", "WWW", "example.com.", 3600),
+		testDnsZoneRecord(0, "a", "mail", "10.0.0.2", 600),
+		testDnsZoneRecord(0, "a", "new", "10.0.0.4", 3600),
+		testDnsZoneRecord(0, "a", "new", "10.0.0.4", 3600),
+	}
+
+	creates, updates, deletes := diffDnsZoneRecords(current, wanted)
+
+	if len(creates) != 1 || *creates[0].Host != "new" {
+		t.Errorf("Unexpected creates: %v", creates)
+	}
+
+	if len(updates) != 1 || sl.Get(updates[0].Id, 0) != 5 || *updates[0].Ttl != 600 {
+		t.Errorf("Unexpected updates: %v", updates)
+	}
+
+	deleteIds := []int{}
+	for _, record := range deletes {
+		deleteIds = append(deleteIds, *record.Id)
+	}
+	sort.Ints(deleteIds)
+
+	// The duplicate mail record is deleted, and the SOA and NS records of the domain are kept.
+	if fmt.Sprint(deleteIds) != "[6 7]" {
+		t.Errorf("Unexpected deletes: %v", deleteIds)
+	}
+}
+
+func TestOwnedDnsZoneRecords(t *testing.T) {
+	current := []datatypes.Dns_Domain_ResourceRecord{
+		testDnsZoneRecord(1, "ns", "@", "ns1.softlayer.com.", 86400),
+		testDnsZoneRecord(2, "a", "@", "10.0.0.1", 86400),
+		testDnsZoneRecord(3, "a", "www", "10.0.0.2", 3600),
+		testDnsZoneRecord(4, "a", "old", "10.0.0.3", 3600),
+		testDnsZoneRecord(5, "a", "mail", "10.0.0.4", 3600),
+		testDnsZoneRecord(6, "a", "mail", "10.0.0.4", 3600),
+		testDnsZoneRecord(7, "txt", "other", "owned by another resource", 3600),
+	}
+
+	wanted := []datatypes.Dns_Domain_ResourceRecord{
+		testDnsZoneRecord(0, "a", "@", "10.0.0.1", 86400),
+		testDnsZoneRecord(0, "a", "www", "10.0.0.2", 3600),
+		testDnsZoneRecord(0, "a", "mail", "10.0.0.4", 3600),
+	}
+
+	records := ownedDnsZoneRecords(current, wanted, map[int]bool{4: true, 6: true}, "10.0.0.1")
+
+	ids := []int{}
+	for _, record := range records {
+		ids = append(ids, *record.Id)
+	}
+	sort.Ints(ids)
+
+	// The records of other resources and the duplicate mail record which isn't owned are left alone.
+	if fmt.Sprint(ids) != "[2 3 4 6]" {
+		t.Errorf("Unexpected owned records: %v", ids)
+	}
+
+	creates, updates, deletes := diffDnsZoneRecords(records, wanted)
+	if len(creates) != 0 || len(updates) != 0 || len(deletes) != 1 || *deletes[0].Id != 4 {
+		t.Errorf("Unexpected changes: %v %v %v", creates, updates, deletes)
+	}
+}
+
+func testDnsZoneRecord(id int, recordType string, host string, data string, ttl int) datatypes.Dns_Domain_ResourceRecord {
+	record := datatypes.Dns_Domain_ResourceRecord{
+		Type: sl.String(recordType),
+		Host: sl.String(host),
+		Data: sl.String(data),
+		Ttl:  sl.Int(ttl),
+	}
+
+	if id != 0 {
+		record.Id = sl.Int(id)
+	}
+
+	return record
+}
+
+func testFormatDnsZoneRecord(record datatypes.Dns_Domain_ResourceRecord) string {
+	formatted := fmt.Sprintf("%s %s %d %s", *record.Type, *record.Host, *record.Ttl, *record.Data)
+
+	if record.MxPriority != nil {
+		formatted += fmt.Sprintf(" mx=%d", *record.MxPriority)
+	}
+
+	if record.Service != nil {
+		formatted += fmt.Sprintf(" srv=%s.%s %d %d %d",
+			*record.Service, *record.Protocol, *record.Priority, *record.Weight, *record.Port)
+	}
+
+	return formatted
+}
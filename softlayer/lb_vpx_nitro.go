@@ -0,0 +1,343 @@
+package softlayer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+// Parts of the NetScaler configuration which the SoftLayer API doesn't cover are managed through the NITRO
+// REST API of the VPX, on its management IP address (NSIP).
+
+const (
+	// The NITRO endpoint can be replaced, e.g. with a local stand-in for testing. The endpoint is the base
+	// URL of the API, e.g. http://127.0.0.1:8080/nitro/v1.
+	NitroEndpointEnv = "SOFTLAYER_NITRO_ENDPOINT"
+
+	// Persistence and health monitors are configured through NITRO from VPX 10.5 on.
+	nitroMinVersion = "10.5"
+
+	// The NITRO error code of a missing resource.
+	nitroErrNoSuchResource = 258
+)
+
+// nitroHttpClient sends the NITRO requests, which time out instead of hanging on an unreachable VPX. NITRO is
+// reached over HTTP on the private management IP address, as the VPX only has a self-signed certificate.
+var nitroHttpClient = &http.Client{
+	Timeout: 60 * time.Second,
+}
+
+// vpxPersistenceTypes maps the persistence of a VIP to the persistence type of the NetScaler virtual server.
+var vpxPersistenceTypes = map[string]string{
+	"sourceip": "SOURCEIP",
+	"cookie":   "COOKIEINSERT",
+}
+
+type nitroClient struct {
+	baseURL  string
+	address  string
+	username string
+	password string
+}
+
+// nitroError is the body of a NITRO error response.
+type nitroError struct {
+	ErrorCode int    `json:"errorcode"`
+	Message   string `json:"message"`
+	Severity  string `json:"severity"`
+}
+
+func (e nitroError) Error() string {
+	return fmt.Sprintf("%s (%d)", e.Message, e.ErrorCode)
+}
+
+// isNitroNotFound returns true if the error is a NITRO error for a missing resource.
+func isNitroNotFound(err error) bool {
+	nitroErr, ok := err.(nitroError)
+	return ok && nitroErr.ErrorCode == nitroErrNoSuchResource
+}
+
+// nitroInt is a number in a NITRO response. Depending on the NetScaler release, numbers are returned as JSON
+// numbers or as strings.
+type nitroInt int
+
+func (i *nitroInt) UnmarshalJSON(data []byte) error {
+	value, err := strconv.Atoi(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+
+	*i = nitroInt(value)
+	return nil
+}
+
+// nitroMonitor is a NetScaler load balancing monitor.
+type nitroMonitor struct {
+	MonitorName string   `json:"monitorname"`
+	Type        string   `json:"type"`
+	HttpRequest string   `json:"httprequest,omitempty"`
+	RespCode    []string `json:"respcode,omitempty"`
+	Interval    nitroInt `json:"interval,omitempty"`
+	RespTimeout nitroInt `json:"resptimeout,omitempty"`
+}
+
+// getNitroClient returns a NITRO client for a VPX, using the nsroot credentials which SoftLayer keeps for
+// the VPX.
+func getNitroClient(sess *session.Session, nadcId int) (*nitroClient, error) {
+	nadc, err := services.GetNetworkApplicationDeliveryControllerService(sess).
+		Id(nadcId).
+		Mask("id,managementIpAddress,password[username,password]").
+		GetObject()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving network application delivery controller: %s", err)
+	}
+
+	if nadc.ManagementIpAddress == nil || nadc.Password == nil {
+		return nil, fmt.Errorf("No management IP address or credentials found for VPX %d", nadcId)
+	}
+
+	client := &nitroClient{
+		baseURL:  fmt.Sprintf("http://%s/nitro/v1", *nadc.ManagementIpAddress),
+		address:  *nadc.ManagementIpAddress,
+		username: sl.Get(nadc.Password.Username, "nsroot").(string),
+		password: sl.Get(nadc.Password.Password, "").(string),
+	}
+
+	if endpoint := os.Getenv(NitroEndpointEnv); endpoint != "" {
+		client.baseURL = strings.TrimSuffix(endpoint, "/")
+	}
+
+	return client, nil
+}
+
+// getNitroClientForConfig returns a NITRO client for a VPX which is recent enough to be configured through
+// NITRO.
+func getNitroClientForConfig(sess *session.Session, nadcId int) (*nitroClient, error) {
+	nadc, err := services.GetNetworkApplicationDeliveryControllerService(sess).
+		Id(nadcId).
+		Mask("id,description").
+		GetObject()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving network application delivery controller: %s", err)
+	}
+
+	version, _, _ := parseVPXDescription(sl.Get(nadc.Description, "").(string))
+	if !vpxVersionAtLeast(version, nitroMinVersion) {
+		return nil, fmt.Errorf(
+			"Persistence and health monitors require VPX %s or later, VPX %d is version %s",
+			nitroMinVersion, nadcId, version)
+	}
+
+	return getNitroClient(sess, nadcId)
+}
+
+// vpxVersionAtLeast compares VPX versions, e.g. "10.1" and "10.5".
+func vpxVersionAtLeast(version string, minVersion string) bool {
+	parts := strings.Split(version, ".")
+	minParts := strings.Split(minVersion, ".")
+
+	for i, minPart := range minParts {
+		if i >= len(parts) {
+			return false
+		}
+
+		number, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return false
+		}
+
+		minNumber, _ := strconv.Atoi(minPart)
+		if number != minNumber {
+			return number > minNumber
+		}
+	}
+
+	return true
+}
+
+// request sends a NITRO request, e.g. request("POST", "config/hanode", ...). The body and the result are
+// JSON encoded, and either can be nil.
+func (c *nitroClient) request(method string, path string, body interface{}, result interface{}) error {
+	var reqBody []byte
+	if body != nil {
+		var err error
+		reqBody, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+"/"+path, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-NITRO-USER", c.username)
+	req.Header.Set("X-NITRO-PASS", c.password)
+	req.Header.Set("Content-Type", "application/json")
+
+	log.Printf("[DEBUG] NITRO request: %s %s", method, path)
+
+	resp, err := nitroHttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		nitroErr := nitroError{}
+		if json.Unmarshal(respBody, &nitroErr) == nil && nitroErr.Message != "" {
+			return nitroErr
+		}
+		return fmt.Errorf("NITRO %s %s: %s", method, path, resp.Status)
+	}
+
+	if result != nil && len(respBody) > 0 {
+		return json.Unmarshal(respBody, result)
+	}
+
+	return nil
+}
+
+// saveConfig saves the running configuration, so it survives a reboot of the VPX.
+func (c *nitroClient) saveConfig() error {
+	return c.request("POST", "config/nsconfig?action=save", map[string]interface{}{
+		"nsconfig": map[string]interface{}{},
+	}, nil)
+}
+
+// getPersistence returns the persistence of a virtual server, e.g. "sourceip", or "" if the virtual server
+// has no persistence.
+func (c *nitroClient) getPersistence(vserverName string) (string, error) {
+	result := struct {
+		LbVserver []struct {
+			PersistenceType string `json:"persistencetype"`
+		} `json:"lbvserver"`
+	}{}
+
+	err := c.request("GET", "config/lbvserver/"+url.QueryEscape(vserverName), nil, &result)
+	if err != nil {
+		return "", err
+	}
+
+	if len(result.LbVserver) == 0 {
+		return "", nitroError{ErrorCode: nitroErrNoSuchResource, Message: "No such resource"}
+	}
+
+	for persistence, persistenceType := range vpxPersistenceTypes {
+		if persistenceType == result.LbVserver[0].PersistenceType {
+			return persistence, nil
+		}
+	}
+
+	return "", nil
+}
+
+// setPersistence sets the persistence of a virtual server. An empty persistence removes the persistence.
+func (c *nitroClient) setPersistence(vserverName string, persistence string) error {
+	persistenceType, ok := vpxPersistenceTypes[persistence]
+	if !ok {
+		persistenceType = "NONE"
+	}
+
+	err := c.request("PUT", "config/lbvserver", map[string]interface{}{
+		"lbvserver": map[string]interface{}{
+			"name":            vserverName,
+			"persistencetype": persistenceType,
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.saveConfig()
+}
+
+// getMonitor returns a load balancing monitor.
+func (c *nitroClient) getMonitor(monitorName string) (*nitroMonitor, error) {
+	result := struct {
+		LbMonitor []nitroMonitor `json:"lbmonitor"`
+	}{}
+
+	err := c.request("GET", "config/lbmonitor/"+url.QueryEscape(monitorName), nil, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.LbMonitor) == 0 {
+		return nil, nitroError{ErrorCode: nitroErrNoSuchResource, Message: "No such resource"}
+	}
+
+	return &result.LbMonitor[0], nil
+}
+
+// setServiceMonitor creates or updates the load balancing monitor of a service, and binds it to the service.
+func (c *nitroClient) setServiceMonitor(serviceName string, monitor nitroMonitor) error {
+	_, err := c.getMonitor(monitor.MonitorName)
+	switch {
+	case isNitroNotFound(err):
+		err = c.request("POST", "config/lbmonitor", map[string]interface{}{"lbmonitor": monitor}, nil)
+		if err != nil {
+			return err
+		}
+
+		err = c.request("POST", "config/service_lbmonitor_binding", map[string]interface{}{
+			"service_lbmonitor_binding": map[string]interface{}{
+				"name":         serviceName,
+				"monitor_name": monitor.MonitorName,
+			},
+		}, nil)
+	case err == nil:
+		err = c.request("PUT", "config/lbmonitor", map[string]interface{}{"lbmonitor": monitor}, nil)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return c.saveConfig()
+}
+
+// deleteServiceMonitor unbinds the load balancing monitor of a service and deletes it. A missing monitor is
+// ignored.
+func (c *nitroClient) deleteServiceMonitor(serviceName string, monitorName string) error {
+	monitor, err := c.getMonitor(monitorName)
+	if isNitroNotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	err = c.request("DELETE", fmt.Sprintf("config/service_lbmonitor_binding/%s?args=monitor_name:%s",
+		url.QueryEscape(serviceName), url.QueryEscape(monitorName)), nil, nil)
+	if err != nil && !isNitroNotFound(err) {
+		return err
+	}
+
+	err = c.request("DELETE", fmt.Sprintf("config/lbmonitor/%s?args=type:%s",
+		url.QueryEscape(monitorName), url.QueryEscape(monitor.Type)), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.saveConfig()
+}
@@ -0,0 +1,169 @@
+package softlayer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testNitroServer is a minimal local stand-in for the NITRO API of a VPX, with virtual servers and monitors.
+func testNitroServer(t *testing.T) (*httptest.Server, map[string]string, map[string]map[string]interface{}) {
+	vservers := map[string]string{"test_vip": "NONE"}
+	monitors := map[string]map[string]interface{}{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-NITRO-USER") != "nsroot" || r.Header.Get("X-NITRO-PASS") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"errorcode": 354, "message": "Invalid username or password", "severity": "ERROR"}`))
+			return
+		}
+
+		notFound := func() {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errorcode": 258, "message": "No such resource", "severity": "ERROR"}`))
+		}
+
+		body := map[string]map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		path := strings.TrimPrefix(r.URL.Path, "/nitro/v1/config/")
+		switch {
+		case path == "nsconfig" || path == "service_lbmonitor_binding" ||
+			strings.HasPrefix(path, "service_lbmonitor_binding/"):
+		case r.Method == "GET" && strings.HasPrefix(path, "lbvserver/"):
+			persistenceType, ok := vservers[strings.TrimPrefix(path, "lbvserver/")]
+			if !ok {
+				notFound()
+				return
+			}
+			w.Write([]byte(`{"errorcode": 0, "lbvserver": [{"persistencetype": "` + persistenceType + `"}]}`))
+		case r.Method == "PUT" && path == "lbvserver":
+			vservers[body["lbvserver"]["name"].(string)] = body["lbvserver"]["persistencetype"].(string)
+		case r.Method == "GET" && strings.HasPrefix(path, "lbmonitor/"):
+			monitor, ok := monitors[strings.TrimPrefix(path, "lbmonitor/")]
+			if !ok {
+				notFound()
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"lbmonitor": []interface{}{monitor}})
+		case (r.Method == "POST" || r.Method == "PUT") && path == "lbmonitor":
+			monitors[body["lbmonitor"]["monitorname"].(string)] = body["lbmonitor"]
+		case r.Method == "DELETE" && strings.HasPrefix(path, "lbmonitor/"):
+			delete(monitors, strings.TrimPrefix(path, "lbmonitor/"))
+		default:
+			t.Errorf("Unexpected NITRO request: %s %s", r.Method, r.URL)
+		}
+	}))
+
+	return server, vservers, monitors
+}
+
+func TestNitroClientPersistence(t *testing.T) {
+	server, vservers, _ := testNitroServer(t)
+	defer server.Close()
+
+	client := &nitroClient{baseURL: server.URL + "/nitro/v1", username: "nsroot", password: "secret"}
+
+	if err := client.setPersistence("test_vip", "cookie"); err != nil {
+		t.Fatal(err)
+	}
+
+	if vservers["test_vip"] != "COOKIEINSERT" {
+		t.Fatalf("Unexpected persistence type: %s", vservers["test_vip"])
+	}
+
+	if persistence, err := client.getPersistence("test_vip"); err != nil || persistence != "cookie" {
+		t.Fatalf("Unexpected persistence: %s, %v", persistence, err)
+	}
+
+	if err := client.setPersistence("test_vip", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if persistence, err := client.getPersistence("test_vip"); err != nil || persistence != "" {
+		t.Fatalf("Unexpected persistence: %s, %v", persistence, err)
+	}
+
+	if _, err := client.getPersistence("missing_vip"); !isNitroNotFound(err) {
+		t.Fatalf("Expected a missing resource error, got %v", err)
+	}
+}
+
+func TestNitroClientServiceMonitor(t *testing.T) {
+	server, _, monitors := testNitroServer(t)
+	defer server.Close()
+
+	client := &nitroClient{baseURL: server.URL + "/nitro/v1", username: "nsroot", password: "secret"}
+
+	monitor := nitroMonitor{
+		MonitorName: "test_service_monitor",
+		Type:        "HTTP",
+		HttpRequest: "GET /health",
+		RespCode:    []string{"200"},
+		Interval:    5,
+		RespTimeout: 2,
+	}
+
+	if err := client.setServiceMonitor("test_service", monitor); err != nil {
+		t.Fatal(err)
+	}
+
+	// An existing monitor is updated.
+	monitor.Interval = 10
+	if err := client.setServiceMonitor("test_service", monitor); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := client.getMonitor("test_service_monitor")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found.HttpRequest != "GET /health" || found.Interval != 10 || found.RespTimeout != 2 ||
+		len(found.RespCode) != 1 || found.RespCode[0] != "200" {
+		t.Fatalf("Unexpected monitor: %+v", found)
+	}
+
+	if err := client.deleteServiceMonitor("test_service", "test_service_monitor"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(monitors) != 0 {
+		t.Fatalf("Monitor still exists: %v", monitors)
+	}
+
+	// A missing monitor is ignored.
+	if err := client.deleteServiceMonitor("test_service", "test_service_monitor"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNitroClientError(t *testing.T) {
+	server, _, _ := testNitroServer(t)
+	defer server.Close()
+
+	client := &nitroClient{baseURL: server.URL + "/nitro/v1", username: "nsroot", password: "wrong"}
+
+	err := client.setPersistence("test_vip", "sourceip")
+	if err == nil || err.Error() != "Invalid username or password (354)" {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestVpxVersionAtLeast(t *testing.T) {
+	cases := map[string]bool{
+		"10.1": false,
+		"10.5": true,
+		"11.0": true,
+		"9.3":  false,
+		"":     false,
+	}
+
+	for version, expected := range cases {
+		if vpxVersionAtLeast(version, nitroMinVersion) != expected {
+			t.Errorf("vpxVersionAtLeast(%q, %q) should be %t", version, nitroMinVersion, expected)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package softlayer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/softlayer/softlayer-go/session"
+)
+
+// Object storage containers and objects are managed through the OpenStack Swift API of the object
+// storage cluster, which is not part of the SoftLayer API.
+
+const (
+	ObjectStorageDefaultDatacenter = "dal05"
+
+	// The Swift authentication endpoint can be replaced, e.g. with a local Swift for testing.
+	ObjectStorageAuthURLEnv = "SOFTLAYER_OBJECTSTORAGE_AUTH_URL"
+)
+
+type swiftClient struct {
+	StorageURL string
+	Token      string
+
+	authURL string
+	user    string
+	key     string
+	mutex   sync.Mutex
+}
+
+// swiftHttpClient sends the Swift requests. The timeout leaves room for uploads of large objects.
+var swiftHttpClient = &http.Client{
+	Timeout: 10 * time.Minute,
+}
+
+var (
+	swiftClients      = map[string]*swiftClient{}
+	swiftClientsMutex sync.Mutex
+)
+
+// getObjectStorageAuthURL returns the Swift authentication endpoint of the object storage cluster in
+// the given datacenter.
+func getObjectStorageAuthURL(datacenter string) string {
+	if authURL := os.Getenv(ObjectStorageAuthURLEnv); authURL != "" {
+		return authURL
+	}
+
+	return fmt.Sprintf("https://%s.objectstorage.softlayer.net/auth/v1.0", datacenter)
+}
+
+// getObjectStoragePrivateAuthURL returns the Swift authentication endpoint of the object storage cluster in
+// the given datacenter on the private network.
+func getObjectStoragePrivateAuthURL(datacenter string) string {
+	return fmt.Sprintf("https://%s.objectstorage.service.networklayer.com/auth/v1.0", datacenter)
+}
+
+// getSwiftClient authenticates to an object storage account with the SoftLayer username and API key.
+// Clients are cached, so each account is only authenticated once.
+func getSwiftClient(sess *session.Session, accountName string, datacenter string) (*swiftClient, error) {
+	authURL := getObjectStorageAuthURL(datacenter)
+	user := accountName + ":" + sess.UserName
+
+	swiftClientsMutex.Lock()
+	defer swiftClientsMutex.Unlock()
+
+	if client, ok := swiftClients[authURL+" "+user]; ok {
+		return client, nil
+	}
+
+	client, err := authenticateSwift(authURL, user, sess.APIKey)
+	if err != nil {
+		return nil, err
+	}
+
+	swiftClients[authURL+" "+user] = client
+	return client, nil
+}
+
+func authenticateSwift(authURL string, user string, key string) (*swiftClient, error) {
+	req, err := http.NewRequest("GET", authURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Auth-User", user)
+	req.Header.Set("X-Auth-Key", key)
+
+	resp, err := swiftHttpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Error authenticating to object storage: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("Error authenticating to object storage: %s", resp.Status)
+	}
+
+	client := &swiftClient{
+		StorageURL: resp.Header.Get("X-Storage-Url"),
+		Token:      resp.Header.Get("X-Auth-Token"),
+		authURL:    authURL,
+		user:       user,
+		key:        key,
+	}
+
+	if client.StorageURL == "" || client.Token == "" {
+		return nil, fmt.Errorf("Error authenticating to object storage: no storage URL or token returned")
+	}
+
+	return client, nil
+}
+
+// swiftNotFound is returned when a container or an object doesn't exist.
+type swiftNotFound struct {
+	path string
+}
+
+func (e swiftNotFound) Error() string {
+	return fmt.Sprintf("%s not found", e.path)
+}
+
+func isSwiftNotFound(err error) bool {
+	_, ok := err.(swiftNotFound)
+	return ok
+}
+
+// Do sends a request for a container or an object path and returns the response headers. The body of
+// the response is discarded. Expired tokens are renewed once.
+func (c *swiftClient) Do(method string, path string, headers map[string]string, body []byte) (http.Header, error) {
+	resp, err := c.do(method, path, headers, body)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		log.Printf("[DEBUG] Object storage token expired, authenticating again")
+
+		var renewed *swiftClient
+		renewed, err = authenticateSwift(c.authURL, c.user, c.key)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mutex.Lock()
+		c.StorageURL, c.Token = renewed.StorageURL, renewed.Token
+		c.mutex.Unlock()
+
+		resp, err = c.do(method, path, headers, body)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, swiftNotFound{path}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%s %s: %s", method, path, resp.Status)
+	}
+
+	return resp.Header, nil
+}
+
+func (c *swiftClient) do(method string, path string, headers map[string]string, body []byte) (*http.Response, error) {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = strings.Replace(url.QueryEscape(segment), "+", "%20", -1)
+	}
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	c.mutex.Lock()
+	storageURL, token := c.StorageURL, c.Token
+	c.mutex.Unlock()
+
+	req, err := http.NewRequest(method, storageURL+"/"+strings.Join(segments, "/"), bodyReader)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Auth-Token", token)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	log.Printf("[DEBUG] Object storage request: %s %s", method, path)
+
+	resp, err := swiftHttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	io.Copy(ioutil.Discard, resp.Body)
+
+	return resp, nil
+}
+
+// getSwiftMetadata returns the metadata with the given header prefix, e.g. X-Container-Meta-. The keys
+// are lower case as HTTP headers are case insensitive.
+func getSwiftMetadata(headers http.Header, prefix string) map[string]string {
+	metadata := map[string]string{}
+	for name := range headers {
+		if strings.HasPrefix(strings.ToLower(name), strings.ToLower(prefix)) {
+			metadata[strings.ToLower(name[len(prefix):])] = headers.Get(name)
+		}
+	}
+	return metadata
+}
+
+// keepSwiftMetadataCase returns the metadata with the keys in the case of the given keys, e.g. the keys in
+// the configuration, so keys with upper case letters don't change.
+func keepSwiftMetadataCase(metadata map[string]string, keys map[string]interface{}) map[string]string {
+	result := map[string]string{}
+	for key, value := range metadata {
+		result[key] = value
+	}
+
+	for key := range keys {
+		lowerKey := strings.ToLower(key)
+		if value, ok := result[lowerKey]; ok && key != lowerKey {
+			delete(result, lowerKey)
+			result[key] = value
+		}
+	}
+
+	return result
+}
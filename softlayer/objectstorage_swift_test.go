@@ -0,0 +1,142 @@
+package softlayer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// testSwiftServer is a minimal local stand-in for the Swift API of an object storage cluster.
+func testSwiftServer(t *testing.T) (*httptest.Server, map[string][]byte) {
+	objects := map[string][]byte{}
+	tokens := 0
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/v1.0" {
+			if r.Header.Get("X-Auth-User") != "SLOS123-1:testuser" || r.Header.Get("X-Auth-Key") != "testkey" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			tokens++
+			w.Header().Set("X-Auth-Token", fmt.Sprintf("token%d", tokens))
+			w.Header().Set("X-Storage-Url", server.URL+"/v1/AUTH_test")
+			return
+		}
+
+		if r.Header.Get("X-Auth-Token") != fmt.Sprintf("token%d", tokens) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		path := r.URL.Path[len("/v1/AUTH_test/"):]
+		switch r.Method {
+		case "PUT":
+			body, _ := ioutil.ReadAll(r.Body)
+			if etag := r.Header.Get("ETag"); etag != "" && etag != getMD5(body) {
+				w.WriteHeader(422)
+				return
+			}
+			objects[path] = body
+			w.WriteHeader(http.StatusCreated)
+		case "HEAD":
+			body, ok := objects[path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("ETag", getMD5(body))
+			w.Header().Set("X-Container-Meta-Owner", "terraform")
+			w.WriteHeader(http.StatusNoContent)
+		case "DELETE":
+			if _, ok := objects[path]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			delete(objects, path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+
+	return server, objects
+}
+
+func TestSwiftClient(t *testing.T) {
+	server, objects := testSwiftServer(t)
+	defer server.Close()
+
+	_, err := authenticateSwift(server.URL+"/auth/v1.0", "SLOS123-1:testuser", "wrongkey")
+	if err == nil {
+		t.Fatalf("Expected authentication with a wrong key to fail")
+	}
+
+	client, err := authenticateSwift(server.URL+"/auth/v1.0", "SLOS123-1:testuser", "testkey")
+	if err != nil {
+		t.Fatalf("Error authenticating: %s", err)
+	}
+
+	_, err = client.Do("PUT", "container/dir/object name", map[string]string{"ETag": getMD5([]byte("data"))}, []byte("data"))
+	if err != nil {
+		t.Fatalf("Error uploading object: %s", err)
+	}
+
+	if string(objects["container/dir/object name"]) != "data" {
+		t.Fatalf("Object was not uploaded to the escaped path: %v", objects)
+	}
+
+	_, err = client.Do("PUT", "container/object", map[string]string{"ETag": getMD5([]byte("other"))}, []byte("data"))
+	if err == nil {
+		t.Fatalf("Expected upload with a wrong ETag to fail")
+	}
+
+	// An expired token is renewed
+	client.Token = "expired"
+
+	headers, err := client.Do("HEAD", "container/dir/object name", nil, nil)
+	if err != nil {
+		t.Fatalf("Error retrieving object: %s", err)
+	}
+
+	if headers.Get("ETag") != getMD5([]byte("data")) {
+		t.Fatalf("Unexpected ETag: %s", headers.Get("ETag"))
+	}
+
+	metadata := getSwiftMetadata(headers, "X-Container-Meta-")
+	if len(metadata) != 1 || metadata["owner"] != "terraform" {
+		t.Fatalf("Unexpected metadata: %v", metadata)
+	}
+
+	_, err = client.Do("DELETE", "container/dir/object name", nil, nil)
+	if err != nil {
+		t.Fatalf("Error deleting object: %s", err)
+	}
+
+	_, err = client.Do("HEAD", "container/dir/object name", nil, nil)
+	if !isSwiftNotFound(err) {
+		t.Fatalf("Expected a not found error, got: %v", err)
+	}
+}
+
+func TestKeepSwiftMetadataCase(t *testing.T) {
+	metadata := keepSwiftMetadataCase(
+		map[string]string{"owner": "terraform", "costcenter": "42"},
+		map[string]interface{}{"Owner": "terraform", "Missing": "x"},
+	)
+
+	if len(metadata) != 2 || metadata["Owner"] != "terraform" || metadata["costcenter"] != "42" {
+		t.Fatalf("Unexpected metadata: %v", metadata)
+	}
+}
+
+func TestGetObjectStorageAuthURL(t *testing.T) {
+	if url := getObjectStorageAuthURL("ams01"); url != "https://ams01.objectstorage.softlayer.net/auth/v1.0" {
+		t.Fatalf("Unexpected auth URL: %s", url)
+	}
+
+	if url := getObjectStoragePrivateAuthURL("ams01"); url != "https://ams01.objectstorage.service.networklayer.com/auth/v1.0" {
+		t.Fatalf("Unexpected private auth URL: %s", url)
+	}
+}
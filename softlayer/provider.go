@@ -39,25 +39,52 @@ func Provider() terraform.ResourceProvider {
 			},
 		},
 
+		DataSourcesMap: map[string]*schema.Resource{
+			"softlayer_subnet": dataSourceSoftLayerSubnet(),
+		},
+
 		ResourcesMap: map[string]*schema.Resource{
-			"softlayer_virtual_guest":          resourceSoftLayerVirtualGuest(),
-			"softlayer_ssh_key":                resourceSoftLayerSSHKey(),
-			"softlayer_dns_domain_record":      resourceSoftLayerDnsDomainRecord(),
-			"softlayer_dns_domain":             resourceSoftLayerDnsDomain(),
-			"softlayer_lb_vpx":                 resourceSoftLayerLbVpx(),
-			"softlayer_lb_vpx_vip":             resourceSoftLayerLbVpxVip(),
-			"softlayer_lb_vpx_service":         resourceSoftLayerLbVpxService(),
-			"softlayer_lb_local":               resourceSoftLayerLbLocal(),
-			"softlayer_lb_local_service_group": resourceSoftLayerLbLocalServiceGroup(),
-			"softlayer_lb_local_service":       resourceSoftLayerLbLocalService(),
-			"softlayer_security_certificate":   resourceSoftLayerSecurityCertificate(),
-			"softlayer_user":                   resourceSoftLayerUser(),
-			"softlayer_objectstorage_account":  resourceSoftLayerObjectStorageAccount(),
-			"softlayer_provisioning_hook":      resourceSoftLayerProvisioningHook(),
-			"softlayer_scale_policy":           resourceSoftLayerScalePolicy(),
-			"softlayer_scale_group":            resourceSoftLayerScaleGroup(),
-			"softlayer_basic_monitor":          resourceSoftLayerBasicMonitor(),
-			"softlayer_vlan":                   resourceSoftLayerVlan(),
+			"softlayer_virtual_guest":             resourceSoftLayerVirtualGuest(),
+			"softlayer_ssh_key":                   resourceSoftLayerSSHKey(),
+			"softlayer_dns_domain_record":         resourceSoftLayerDnsDomainRecord(),
+			"softlayer_dns_domain":                resourceSoftLayerDnsDomain(),
+			"softlayer_dns_secondary":             resourceSoftLayerDnsSecondary(),
+			"softlayer_dns_record_set":            resourceSoftLayerDnsRecordSet(),
+			"softlayer_dns_domain_registration":   resourceSoftLayerDnsDomainRegistration(),
+			"softlayer_lb_vpx":                    resourceSoftLayerLbVpx(),
+			"softlayer_lb_vpx_vip":                resourceSoftLayerLbVpxVip(),
+			"softlayer_lb_vpx_service":            resourceSoftLayerLbVpxService(),
+			"softlayer_lb_vpx_service_group":      resourceSoftLayerLbVpxServiceGroup(),
+			"softlayer_global_lb":                 resourceSoftLayerGlobalLb(),
+			"softlayer_global_lb_host":            resourceSoftLayerGlobalLbHost(),
+			"softlayer_lb_local":                  resourceSoftLayerLbLocal(),
+			"softlayer_lb_local_service_group":    resourceSoftLayerLbLocalServiceGroup(),
+			"softlayer_lb_local_service":          resourceSoftLayerLbLocalService(),
+			"softlayer_security_certificate":      resourceSoftLayerSecurityCertificate(),
+			"softlayer_user":                      resourceSoftLayerUser(),
+			"softlayer_objectstorage_account":     resourceSoftLayerObjectStorageAccount(),
+			"softlayer_objectstorage_container":   resourceSoftLayerObjectStorageContainer(),
+			"softlayer_objectstorage_object":      resourceSoftLayerObjectStorageObject(),
+			"softlayer_cos_account":               resourceSoftLayerCosAccount(),
+			"softlayer_cos_credential":            resourceSoftLayerCosCredential(),
+			"softlayer_cos_bucket":                resourceSoftLayerCosBucket(),
+			"softlayer_cdn_account":               resourceSoftLayerCdnAccount(),
+			"softlayer_cdn_origin_pull":           resourceSoftLayerCdnOriginPull(),
+			"softlayer_cdn_token":                 resourceSoftLayerCdnToken(),
+			"softlayer_provisioning_hook":         resourceSoftLayerProvisioningHook(),
+			"softlayer_scale_policy":              resourceSoftLayerScalePolicy(),
+			"softlayer_scale_group":               resourceSoftLayerScaleGroup(),
+			"softlayer_basic_monitor":             resourceSoftLayerBasicMonitor(),
+			"softlayer_network_monitor":           resourceSoftLayerNetworkMonitor(),
+			"softlayer_vlan":                      resourceSoftLayerVlan(),
+			"softlayer_security_group":            resourceSoftLayerSecurityGroup(),
+			"softlayer_security_group_rule":       resourceSoftLayerSecurityGroupRule(),
+			"softlayer_block_storage":             resourceSoftLayerBlockStorage(),
+			"softlayer_file_storage":              resourceSoftLayerFileStorage(),
+			"softlayer_storage_snapshot_schedule": resourceSoftLayerStorageSnapshotSchedule(),
+			"softlayer_storage_snapshot":          resourceSoftLayerStorageSnapshot(),
+			"softlayer_storage_replica":           resourceSoftLayerStorageReplica(),
+			"softlayer_evault":                    resourceSoftLayerEvault(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -0,0 +1,826 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/helpers/location"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	EnduranceType   = "Endurance"
+	PerformanceType = "Performance"
+
+	BlockStorageMask = "id,username,capacityGb,snapshotCapacityGb,iops,storageTierLevel,lunId,serviceResourceBackendIpAddress," +
+		"storageType[keyName],osType[keyName],serviceResource[datacenter[name]]," +
+		"allowedVirtualGuests[id],allowedHardware[id],allowedSubnets[networkIdentifier,cidr],allowedIpAddresses[ipAddress]"
+
+	StoragePackageItemMask = "id,capacity,description,keyName," +
+		"prices[id,locationGroupId,capacityRestrictionType,capacityRestrictionMinimum,capacityRestrictionMaximum," +
+		"categories[id,name,categoryCode]]"
+)
+
+// Endurance volumes are ordered by tier. The tier is given in IOPS per GB, and the storage space
+// prices are restricted by the tier level of the matching tier.
+var enduranceTiers = map[float64]struct {
+	keyName string
+	level   int
+}{
+	0.25: {"LOW_INTENSITY_TIER", 100},
+	2:    {"READHEAVY_TIER", 200},
+	4:    {"WRITEHEAVY_TIER", 300},
+	10:   {"10_IOPS_PER_GB", 1000},
+}
+
+func resourceSoftLayerBlockStorage() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerBlockStorageCreate,
+		Read:     resourceSoftLayerBlockStorageRead,
+		Update:   resourceSoftLayerBlockStorageUpdate,
+		Delete:   resourceSoftLayerBlockStorageDelete,
+		Exists:   resourceSoftLayerBlockStorageExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStorageType,
+			},
+
+			"datacenter": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"capacity": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"iops": {
+				Type:     schema.TypeFloat,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"snapshot_capacity": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"os_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					osTypes := []string{"LINUX", "VMWARE", "WINDOWS_2008", "WINDOWS_GPT", "WINDOWS", "XEN", "HYPER_V"}
+					osType := v.(string)
+					for _, t := range osTypes {
+						if osType == t {
+							return
+						}
+					}
+					errors = append(errors, fmt.Errorf(
+						"Invalid os_type: os_type should be one of %s", strings.Join(osTypes, ", ")))
+					return
+				},
+			},
+
+			"volume_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"target_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"lun_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"allowed_virtual_guest_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Set:      schema.HashInt,
+			},
+
+			"allowed_hardware_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Set:      schema.HashInt,
+			},
+
+			"allowed_subnets": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"allowed_ip_addresses": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func validateStorageType(v interface{}, k string) (ws []string, errors []error) {
+	storageType := v.(string)
+	if storageType != EnduranceType && storageType != PerformanceType {
+		errors = append(errors, fmt.Errorf(
+			"Invalid %s: %s should be either '%s' or '%s'", k, k, EnduranceType, PerformanceType))
+	}
+	return
+}
+
+func resourceSoftLayerBlockStorageCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	storageType := d.Get("type").(string)
+	capacity := d.Get("capacity").(int)
+	iops := d.Get("iops").(float64)
+	datacenter := d.Get("datacenter").(string)
+	osFormatType := &datatypes.Network_Storage_Iscsi_OS_Type{
+		KeyName: sl.String(d.Get("os_type").(string)),
+	}
+
+	dc, err := location.GetDatacenterByName(sess, datacenter, "id,priceGroups[id]")
+	if err != nil {
+		return fmt.Errorf("Error creating block storage: %s", err)
+	}
+
+	if dc.Id == nil {
+		return fmt.Errorf("Error creating block storage: no datacenter found with name %s", datacenter)
+	}
+
+	pkgId, prices, err := getStorageOrderPrices(sess, storageOrder{
+		storageType:      storageType,
+		storageProtocol:  "iscsi",
+		capacity:         capacity,
+		iops:             iops,
+		snapshotCapacity: d.Get("snapshot_capacity").(int),
+	}, dc)
+	if err != nil {
+		return fmt.Errorf("Error creating block storage: %s", err)
+	}
+
+	orderContainer := datatypes.Container_Product_Order{
+		PackageId: sl.Int(pkgId),
+		Location:  sl.String(strconv.Itoa(*dc.Id)),
+		Prices:    prices,
+		Quantity:  sl.Int(1),
+	}
+
+	var productOrderContainer interface{}
+	if storageType == EnduranceType {
+		productOrderContainer = &datatypes.Container_Product_Order_Network_Storage_Enterprise{
+			Container_Product_Order: orderContainer,
+			OsFormatType:            osFormatType,
+		}
+	} else {
+		productOrderContainer = &datatypes.Container_Product_Order_Network_PerformanceStorage_Iscsi{
+			Container_Product_Order_Network_PerformanceStorage: datatypes.Container_Product_Order_Network_PerformanceStorage{
+				Container_Product_Order: orderContainer,
+			},
+			OsFormatType: osFormatType,
+		}
+	}
+
+	log.Println("[INFO] Creating block storage")
+
+	receipt, err := services.GetProductOrderService(sess).
+		PlaceOrder(productOrderContainer, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of block storage: %s", err)
+	}
+
+	storage, err := findStorageByOrderId(sess, *receipt.OrderId)
+	if err != nil {
+		return fmt.Errorf("Error finding created block storage: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", *storage.Id))
+	log.Printf("[INFO] Block storage ID: %s", d.Id())
+
+	err = updateStorageAllowedHosts(d, meta, *storage.Id)
+	if err != nil {
+		return fmt.Errorf("Error updating allowed hosts of block storage: %s", err)
+	}
+
+	return resourceSoftLayerBlockStorageRead(d, meta)
+}
+
+func resourceSoftLayerBlockStorageRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	storageId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	storage, err := services.GetNetworkStorageService(sess).
+		Id(storageId).
+		Mask(BlockStorageMask).
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving block storage: %s", err)
+	}
+
+	setStorageResourceData(d, storage)
+
+	d.Set("target_address", sl.Get(storage.ServiceResourceBackendIpAddress, ""))
+	d.Set("lun_id", sl.Get(storage.LunId, ""))
+
+	if storage.OsType != nil {
+		d.Set("os_type", sl.Get(storage.OsType.KeyName, ""))
+	}
+
+	return nil
+}
+
+func resourceSoftLayerBlockStorageUpdate(d *schema.ResourceData, meta interface{}) error {
+	storageId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	if d.HasChange("snapshot_capacity") {
+		err = upgradeStorageSnapshotSpace(d, meta, storageId, "iscsi")
+		if err != nil {
+			return fmt.Errorf("Error updating snapshot space of block storage: %s", err)
+		}
+	}
+
+	err = updateStorageAllowedHosts(d, meta, storageId)
+	if err != nil {
+		return fmt.Errorf("Error updating allowed hosts of block storage: %s", err)
+	}
+
+	return resourceSoftLayerBlockStorageRead(d, meta)
+}
+
+func resourceSoftLayerBlockStorageDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	storageId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	err = cancelStorage(sess, storageId)
+	if err != nil {
+		return fmt.Errorf("Error deleting block storage: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerBlockStorageExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+
+	storageId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	result, err := services.GetNetworkStorageService(sess).Id(storageId).Mask("id").GetObject()
+	return result.Id != nil && err == nil && *result.Id == storageId, nil
+}
+
+// setStorageResourceData sets the attributes which block and file storage have in common.
+func setStorageResourceData(d *schema.ResourceData, storage datatypes.Network_Storage) {
+	d.Set("volume_name", sl.Get(storage.Username, ""))
+	d.Set("capacity", sl.Get(storage.CapacityGb, 0))
+
+	if snapshotCapacity, err := strconv.Atoi(sl.Get(storage.SnapshotCapacityGb, "").(string)); err == nil {
+		d.Set("snapshot_capacity", snapshotCapacity)
+	}
+
+	if storage.StorageType != nil && storage.StorageType.KeyName != nil {
+		if strings.HasPrefix(*storage.StorageType.KeyName, "ENDURANCE") {
+			d.Set("type", EnduranceType)
+		} else if strings.HasPrefix(*storage.StorageType.KeyName, "PERFORMANCE") {
+			d.Set("type", PerformanceType)
+		}
+	}
+
+	// Performance volumes report their IOPS, endurance volumes report their tier.
+	if storage.StorageTierLevel != nil {
+		for iops, tier := range enduranceTiers {
+			if tier.keyName == *storage.StorageTierLevel {
+				d.Set("iops", iops)
+			}
+		}
+	} else if storage.Iops != nil {
+		iops, err := strconv.ParseFloat(*storage.Iops, 64)
+		if err == nil {
+			d.Set("iops", iops)
+		}
+	}
+
+	if storage.ServiceResource != nil && storage.ServiceResource.Datacenter != nil {
+		d.Set("datacenter", sl.Get(storage.ServiceResource.Datacenter.Name, ""))
+	}
+
+	virtualGuestIds := make([]int, 0, len(storage.AllowedVirtualGuests))
+	for _, guest := range storage.AllowedVirtualGuests {
+		virtualGuestIds = append(virtualGuestIds, *guest.Id)
+	}
+	d.Set("allowed_virtual_guest_ids", virtualGuestIds)
+
+	hardwareIds := make([]int, 0, len(storage.AllowedHardware))
+	for _, hardware := range storage.AllowedHardware {
+		hardwareIds = append(hardwareIds, *hardware.Id)
+	}
+	d.Set("allowed_hardware_ids", hardwareIds)
+
+	subnets := make([]string, 0, len(storage.AllowedSubnets))
+	for _, subnet := range storage.AllowedSubnets {
+		subnets = append(subnets, fmt.Sprintf("%s/%d", *subnet.NetworkIdentifier, *subnet.Cidr))
+	}
+	d.Set("allowed_subnets", subnets)
+
+	ipAddresses := make([]string, 0, len(storage.AllowedIpAddresses))
+	for _, ipAddress := range storage.AllowedIpAddresses {
+		ipAddresses = append(ipAddresses, *ipAddress.IpAddress)
+	}
+	d.Set("allowed_ip_addresses", ipAddresses)
+}
+
+// storageOrder describes the volume to be priced by getStorageOrderPrices. storageProtocol is either
+// "iscsi" or "nfs". Upgrade orders only need the prices of the storage space and the IOPS or tier, and
+// snapshot space orders only need the price of the snapshot space. Snapshot space and replication are
+// only available for endurance volumes.
+type storageOrder struct {
+	storageType      string
+	storageProtocol  string
+	capacity         int
+	iops             float64
+	snapshotCapacity int
+	upgrade          bool
+	snapshotUpgrade  bool
+	replication      bool
+}
+
+// getStorageOrderPrices returns the package id and the item prices needed to order a volume.
+func getStorageOrderPrices(sess *session.Session, order storageOrder, dc datatypes.Location_Datacenter) (
+	int, []datatypes.Product_Item_Price, error) {
+	storageType := order.storageType
+	capacity := order.capacity
+	iops := order.iops
+
+	// Each storage type has its own package which is found by the category of its base item.
+	var baseCategoryCode string
+	if storageType == EnduranceType {
+		baseCategoryCode = "storage_service_enterprise"
+	} else {
+		baseCategoryCode = "performance_storage_" + order.storageProtocol
+	}
+
+	pkgs, err := services.GetProductPackageService(sess).
+		Mask("id,name,description").
+		Filter(filter.Build(
+			filter.Path("categories.categoryCode").Eq(baseCategoryCode),
+			filter.Path("statusCode").Eq("ACTIVE"),
+		)).
+		GetAllObjects()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if len(pkgs) == 0 {
+		return 0, nil, fmt.Errorf("No product packages found for %s", baseCategoryCode)
+	}
+
+	productItems, err := services.GetProductPackageService(sess).
+		Id(*pkgs[0].Id).
+		Mask(StoragePackageItemMask).
+		GetItems()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	matchCapacity := func(value float64) func(item datatypes.Product_Item, price datatypes.Product_Item_Price) bool {
+		return func(item datatypes.Product_Item, price datatypes.Product_Item_Price) bool {
+			return item.Capacity != nil && float64(*item.Capacity) == value
+		}
+	}
+
+	matchAny := func(item datatypes.Product_Item, price datatypes.Product_Item_Price) bool {
+		return true
+	}
+
+	var selectors []storagePriceSelector
+	if storageType == EnduranceType {
+		tier, ok := enduranceTiers[iops]
+		if !ok {
+			return 0, nil, fmt.Errorf(
+				"Endurance storage iops should be one of 0.25, 2, 4 or 10 IOPS per GB, got %g", iops)
+		}
+
+		var storageCategoryCode string
+		if order.storageProtocol == "iscsi" {
+			storageCategoryCode = "storage_block"
+		} else {
+			storageCategoryCode = "storage_file"
+		}
+
+		selectors = []storagePriceSelector{
+			{baseCategoryCode, matchAny},
+			{storageCategoryCode, matchAny},
+			{"storage_tier_level", func(item datatypes.Product_Item, price datatypes.Product_Item_Price) bool {
+				return item.KeyName != nil && *item.KeyName == tier.keyName
+			}},
+			{"performance_storage_space", func(item datatypes.Product_Item, price datatypes.Product_Item_Price) bool {
+				return matchCapacity(float64(capacity))(item, price) &&
+					priceAllowsCapacity(price, "STORAGE_TIER_LEVEL", tier.level)
+			}},
+		}
+	} else {
+		selectors = []storagePriceSelector{
+			{baseCategoryCode, matchAny},
+			{"performance_storage_space", matchCapacity(float64(capacity))},
+			{"performance_storage_iops", func(item datatypes.Product_Item, price datatypes.Product_Item_Price) bool {
+				return matchCapacity(iops)(item, price) &&
+					priceAllowsCapacity(price, "STORAGE_SPACE", capacity)
+			}},
+		}
+	}
+
+	if order.upgrade {
+		selectors = selectors[len(selectors)-2:]
+	} else if order.snapshotUpgrade {
+		selectors = nil
+	}
+
+	if order.snapshotCapacity > 0 || order.replication {
+		if storageType != EnduranceType {
+			return 0, nil, fmt.Errorf("Snapshot space and replication are only available for %s storage", EnduranceType)
+		}
+
+		tierLevel := enduranceTiers[iops].level
+
+		if order.snapshotCapacity > 0 {
+			selectors = append(selectors, storagePriceSelector{"storage_snapshot_space",
+				func(item datatypes.Product_Item, price datatypes.Product_Item_Price) bool {
+					return matchCapacity(float64(order.snapshotCapacity))(item, price) &&
+						priceAllowsCapacity(price, "STORAGE_TIER_LEVEL", tierLevel)
+				}})
+		}
+
+		if order.replication {
+			selectors = append(selectors, storagePriceSelector{"performance_storage_replication",
+				func(item datatypes.Product_Item, price datatypes.Product_Item_Price) bool {
+					return priceAllowsCapacity(price, "STORAGE_TIER_LEVEL", tierLevel)
+				}})
+		}
+	}
+
+	prices, err := selectStorageItemPrices(productItems, selectors, dc)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return *pkgs[0].Id, prices, nil
+}
+
+type storagePriceSelector struct {
+	categoryCode string
+	match        func(item datatypes.Product_Item, price datatypes.Product_Item_Price) bool
+}
+
+// selectStorageItemPrices selects a price for each selector. Like selectVlanItemPrices, prices of the
+// datacenter's location groups are preferred over the standard prices.
+func selectStorageItemPrices(productItems []datatypes.Product_Item, selectors []storagePriceSelector,
+	dc datatypes.Location_Datacenter) ([]datatypes.Product_Item_Price, error) {
+	priceGroups := make([]int, 0, len(dc.PriceGroups))
+	for _, group := range dc.PriceGroups {
+		priceGroups = append(priceGroups, *group.Id)
+	}
+
+	prices := make([]datatypes.Product_Item_Price, 0, len(selectors))
+	for _, selector := range selectors {
+		var selected *datatypes.Product_Item_Price
+
+	items:
+		for _, item := range productItems {
+			for i, price := range item.Prices {
+				if !priceHasCategory(price, selector.categoryCode) || !selector.match(item, price) {
+					continue
+				}
+
+				if price.LocationGroupId == nil {
+					if selected == nil {
+						selected = &item.Prices[i]
+					}
+				} else if contains(priceGroups, *price.LocationGroupId) {
+					selected = &item.Prices[i]
+					break items
+				}
+			}
+		}
+
+		if selected == nil {
+			return nil, fmt.Errorf("No product items matching %s could be found", selector.categoryCode)
+		}
+
+		prices = append(prices, datatypes.Product_Item_Price{Id: selected.Id})
+	}
+
+	return prices, nil
+}
+
+func priceHasCategory(price datatypes.Product_Item_Price, categoryCode string) bool {
+	for _, category := range price.Categories {
+		if category.CategoryCode != nil && *category.CategoryCode == categoryCode {
+			return true
+		}
+	}
+	return false
+}
+
+// priceAllowsCapacity checks the capacity restriction of a price. Prices without a restriction of
+// the given type are always allowed.
+func priceAllowsCapacity(price datatypes.Product_Item_Price, restrictionType string, value int) bool {
+	if price.CapacityRestrictionType == nil || *price.CapacityRestrictionType != restrictionType {
+		return true
+	}
+
+	min, err := strconv.Atoi(sl.Get(price.CapacityRestrictionMinimum, "0").(string))
+	if err != nil {
+		return false
+	}
+
+	max, err := strconv.Atoi(sl.Get(price.CapacityRestrictionMaximum, "0").(string))
+	if err != nil {
+		return false
+	}
+
+	return min <= value && value <= max
+}
+
+func findStorageByOrderId(sess *session.Session, orderId int) (datatypes.Network_Storage, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			storages, err := services.GetAccountService(sess).
+				Filter(filter.Path("networkStorage.billingItem.orderItem.order.id").
+					Eq(strconv.Itoa(orderId)).Build()).
+				Mask("id,activeTransactionCount").
+				GetNetworkStorage()
+			if err != nil {
+				return datatypes.Network_Storage{}, "", err
+			}
+
+			if len(storages) == 1 {
+				// The volume can't be used until it is provisioned.
+				if sl.Get(storages[0].ActiveTransactionCount, uint(0)).(uint) > 0 {
+					return storages[0], "pending", nil
+				}
+				return storages[0], "complete", nil
+			} else if len(storages) == 0 {
+				return nil, "pending", nil
+			} else {
+				return nil, "", fmt.Errorf("Expected one storage, found %d", len(storages))
+			}
+		},
+		Timeout:    45 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	pendingResult, err := stateConf.WaitForState()
+
+	if err != nil {
+		return datatypes.Network_Storage{}, err
+	}
+
+	var result, ok = pendingResult.(datatypes.Network_Storage)
+
+	if ok {
+		return result, nil
+	}
+
+	return datatypes.Network_Storage{},
+		fmt.Errorf("Cannot find storage with order id '%d'", orderId)
+}
+
+// Allowed hosts are granted access to a volume by their SoftLayer object type.
+var storageAllowedHostTypes = map[string]string{
+	"allowed_virtual_guest_ids": "SoftLayer_Virtual_Guest",
+	"allowed_hardware_ids":      "SoftLayer_Hardware",
+	"allowed_subnets":           "SoftLayer_Network_Subnet",
+	"allowed_ip_addresses":      "SoftLayer_Network_Subnet_IpAddress",
+}
+
+// updateStorageAllowedHosts grants and revokes access to a volume so that exactly the virtual guests,
+// hardware, subnets and IP addresses in the configuration are allowed.
+func updateStorageAllowedHosts(d *schema.ResourceData, meta interface{}, storageId int) error {
+	removed := []datatypes.Container_Network_Storage_Host{}
+	added := []datatypes.Container_Network_Storage_Host{}
+
+	for attribute, objectType := range storageAllowedHostTypes {
+		if !d.HasChange(attribute) {
+			continue
+		}
+
+		oldHosts, newHosts := d.GetChange(attribute)
+
+		hosts, err := getStorageHosts(meta, objectType,
+			oldHosts.(*schema.Set).Difference(newHosts.(*schema.Set)).List())
+		if err != nil {
+			return err
+		}
+		removed = append(removed, hosts...)
+
+		hosts, err = getStorageHosts(meta, objectType,
+			newHosts.(*schema.Set).Difference(oldHosts.(*schema.Set)).List())
+		if err != nil {
+			return err
+		}
+		added = append(added, hosts...)
+	}
+
+	service := services.GetNetworkStorageService(meta.(*session.Session)).Id(storageId)
+
+	if len(removed) > 0 {
+		_, err := service.RemoveAccessFromHostList(removed)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(added) > 0 {
+		_, err := service.AllowAccessFromHostList(added)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getStorageHosts converts the configured hosts to host containers. Virtual guests and hardware are
+// configured by id, while subnets and IP addresses are configured by address and need to be looked up.
+func getStorageHosts(meta interface{}, objectType string, hosts []interface{}) (
+	[]datatypes.Container_Network_Storage_Host, error) {
+	containers := make([]datatypes.Container_Network_Storage_Host, 0, len(hosts))
+
+	for _, host := range hosts {
+		var id int
+
+		switch objectType {
+		case "SoftLayer_Network_Subnet":
+			subnetId, err := getSubnetId(host.(string), meta)
+			if err != nil {
+				return nil, err
+			}
+			id = subnetId
+		case "SoftLayer_Network_Subnet_IpAddress":
+			ipAddress, err := services.GetNetworkSubnetIpAddressService(meta.(*session.Session)).
+				GetByIpAddress(sl.String(host.(string)))
+			if err != nil {
+				return nil, err
+			}
+
+			if ipAddress.Id == nil {
+				return nil, fmt.Errorf("No IP address found with address %s", host.(string))
+			}
+			id = *ipAddress.Id
+		default:
+			id = host.(int)
+		}
+
+		containers = append(containers, datatypes.Container_Network_Storage_Host{
+			Id:         sl.Int(id),
+			ObjectType: sl.String(objectType),
+		})
+	}
+
+	return containers, nil
+}
+
+// upgradeStorageSnapshotSpace orders snapshot space for a volume, or a larger snapshot space, and waits
+// until the snapshot space is available. Snapshot space can't be decreased or removed.
+func upgradeStorageSnapshotSpace(d *schema.ResourceData, meta interface{}, storageId int, storageProtocol string) error {
+	sess := meta.(*session.Session)
+
+	oldCapacity, newCapacity := d.GetChange("snapshot_capacity")
+	if newCapacity.(int) < oldCapacity.(int) {
+		return fmt.Errorf("snapshot_capacity can't be decreased from %d to %d", oldCapacity, newCapacity)
+	}
+
+	datacenter := d.Get("datacenter").(string)
+	dc, err := location.GetDatacenterByName(sess, datacenter, "id,priceGroups[id]")
+	if err != nil {
+		return err
+	}
+
+	if dc.Id == nil {
+		return fmt.Errorf("No datacenter found with name %s", datacenter)
+	}
+
+	pkgId, prices, err := getStorageOrderPrices(sess, storageOrder{
+		storageType:      d.Get("type").(string),
+		storageProtocol:  storageProtocol,
+		capacity:         d.Get("capacity").(int),
+		iops:             d.Get("iops").(float64),
+		snapshotCapacity: newCapacity.(int),
+		snapshotUpgrade:  true,
+	}, dc)
+	if err != nil {
+		return err
+	}
+
+	snapshotSpaceOrder := datatypes.Container_Product_Order_Network_Storage_Enterprise_SnapshotSpace{
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: sl.Int(pkgId),
+			Prices:    prices,
+			Quantity:  sl.Int(1),
+		},
+		VolumeId: sl.Int(storageId),
+	}
+
+	// Snapshot space which is added to a volume is a new order, while a larger snapshot space is an upgrade.
+	var productOrderContainer interface{} = &snapshotSpaceOrder
+	if oldCapacity.(int) > 0 {
+		productOrderContainer = &datatypes.Container_Product_Order_Network_Storage_Enterprise_SnapshotSpace_Upgrade{
+			Container_Product_Order_Network_Storage_Enterprise_SnapshotSpace: snapshotSpaceOrder,
+		}
+	}
+
+	log.Printf("[INFO] Ordering %d GB snapshot space for storage: %d", newCapacity, storageId)
+
+	_, err = services.GetProductOrderService(sess).PlaceOrder(productOrderContainer, sl.Bool(false))
+	if err != nil {
+		return err
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			storage, err := services.GetNetworkStorageService(sess).
+				Id(storageId).
+				Mask("id,snapshotCapacityGb,activeTransactionCount").
+				GetObject()
+			if err != nil {
+				return nil, "", err
+			}
+
+			if sl.Get(storage.SnapshotCapacityGb, "").(string) != strconv.Itoa(newCapacity.(int)) ||
+				sl.Get(storage.ActiveTransactionCount, uint(0)).(uint) > 0 {
+				return storage, "pending", nil
+			}
+
+			return storage, "complete", nil
+		},
+		Timeout:    45 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	return err
+}
+
+func cancelStorage(sess *session.Session, storageId int) error {
+	billingItem, err := services.GetNetworkStorageService(sess).Id(storageId).GetBillingItem()
+	if err != nil {
+		return err
+	}
+
+	if billingItem.Id == nil {
+		return fmt.Errorf("No billing item found for storage %d", storageId)
+	}
+
+	log.Printf("[INFO] Cancelling storage: %d", storageId)
+	_, err = services.GetBillingItemService(sess).Id(*billingItem.Id).CancelService()
+
+	return err
+}
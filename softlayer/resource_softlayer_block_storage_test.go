@@ -0,0 +1,216 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerBlockStorage_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerBlockStorageDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerBlockStorageConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerBlockStorageExists("softlayer_block_storage.bs_endurance"),
+					resource.TestCheckResourceAttr(
+						"softlayer_block_storage.bs_endurance", "type", "Endurance"),
+					resource.TestCheckResourceAttr(
+						"softlayer_block_storage.bs_endurance", "capacity", "20"),
+					resource.TestCheckResourceAttr(
+						"softlayer_block_storage.bs_endurance", "iops", "0.25"),
+					resource.TestCheckResourceAttr(
+						"softlayer_block_storage.bs_endurance", "os_type", "LINUX"),
+					resource.TestCheckResourceAttr(
+						"softlayer_block_storage.bs_endurance", "datacenter", "dal06"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_block_storage.bs_endurance", "target_address"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_block_storage.bs_endurance", "lun_id"),
+					resource.TestCheckResourceAttr(
+						"softlayer_block_storage.bs_endurance", "allowed_virtual_guest_ids.#", "0"),
+					testAccCheckSoftLayerBlockStorageExists("softlayer_block_storage.bs_performance"),
+					resource.TestCheckResourceAttr(
+						"softlayer_block_storage.bs_performance", "type", "Performance"),
+					resource.TestCheckResourceAttr(
+						"softlayer_block_storage.bs_performance", "capacity", "20"),
+					resource.TestCheckResourceAttr(
+						"softlayer_block_storage.bs_performance", "iops", "100"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerBlockStorageConfig_allowedHosts,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_block_storage.bs_endurance", "allowed_virtual_guest_ids.#", "1"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerBlockStorageConfig_snapshotSpace,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_block_storage.bs_endurance", "snapshot_capacity", "10"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerBlockStorageDestroy(s *terraform.State) error {
+	service := services.GetNetworkStorageService(testAccProvider.Meta().(*session.Session))
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_block_storage" {
+			continue
+		}
+
+		storageId, _ := strconv.Atoi(rs.Primary.ID)
+
+		// Cancelled volumes stay visible until they are reclaimed, but lose their billing item.
+		billingItem, err := service.Id(storageId).GetBillingItem()
+
+		if err == nil && billingItem.Id != nil {
+			return fmt.Errorf("Block storage %d still exists", storageId)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckSoftLayerBlockStorageExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		storageId, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := services.GetNetworkStorageService(testAccProvider.Meta().(*session.Session))
+		foundStorage, err := service.Id(storageId).GetObject()
+
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(*foundStorage.Id) != rs.Primary.ID {
+			return fmt.Errorf("Record not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerBlockStorageConfig_basic = `
+resource "softlayer_virtual_guest" "storagevm1" {
+    name = "storagevm1"
+    domain = "terraformuat.softlayer.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "dal06"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    cpu = 1
+    ram = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_block_storage" "bs_endurance" {
+    type = "Endurance"
+    datacenter = "dal06"
+    capacity = 20
+    iops = 0.25
+    os_type = "LINUX"
+}
+
+resource "softlayer_block_storage" "bs_performance" {
+    type = "Performance"
+    datacenter = "dal06"
+    capacity = 20
+    iops = 100
+    os_type = "LINUX"
+}
+`
+
+const testAccCheckSoftLayerBlockStorageConfig_allowedHosts = `
+resource "softlayer_virtual_guest" "storagevm1" {
+    name = "storagevm1"
+    domain = "terraformuat.softlayer.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "dal06"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    cpu = 1
+    ram = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_block_storage" "bs_endurance" {
+    type = "Endurance"
+    datacenter = "dal06"
+    capacity = 20
+    iops = 0.25
+    os_type = "LINUX"
+    allowed_virtual_guest_ids = ["${softlayer_virtual_guest.storagevm1.id}"]
+}
+
+resource "softlayer_block_storage" "bs_performance" {
+    type = "Performance"
+    datacenter = "dal06"
+    capacity = 20
+    iops = 100
+    os_type = "LINUX"
+}
+`
+
+const testAccCheckSoftLayerBlockStorageConfig_snapshotSpace = `
+resource "softlayer_virtual_guest" "storagevm1" {
+    name = "storagevm1"
+    domain = "terraformuat.softlayer.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "dal06"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    cpu = 1
+    ram = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_block_storage" "bs_endurance" {
+    type = "Endurance"
+    datacenter = "dal06"
+    capacity = 20
+    iops = 0.25
+    os_type = "LINUX"
+    allowed_virtual_guest_ids = ["${softlayer_virtual_guest.storagevm1.id}"]
+    snapshot_capacity = 10
+}
+
+resource "softlayer_block_storage" "bs_performance" {
+    type = "Performance"
+    datacenter = "dal06"
+    capacity = 20
+    iops = 100
+    os_type = "LINUX"
+}
+`
@@ -0,0 +1,295 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	CdnCategoryCode = "cdn"
+
+	// Origin pull and token authentication are configured for HTTP content.
+	CdnMediaType = "HTTP"
+)
+
+func resourceSoftLayerCdnAccount() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerCdnAccountCreate,
+		Read:     resourceSoftLayerCdnAccountRead,
+		Update:   resourceSoftLayerCdnAccountUpdate,
+		Delete:   resourceSoftLayerCdnAccountDelete,
+		Exists:   resourceSoftLayerCdnAccountExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"note": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"logging_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"token_authentication_directories": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"origin_pull_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerCdnAccountCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	pkgs, err := services.GetProductPackageService(sess).
+		Mask("id").
+		Filter(filter.Build(
+			filter.Path("categories.categoryCode").Eq(CdnCategoryCode),
+			filter.Path("statusCode").Eq("ACTIVE"),
+		)).
+		GetAllObjects()
+	if err != nil {
+		return fmt.Errorf("Error creating CDN account: %s", err)
+	}
+
+	if len(pkgs) == 0 {
+		return fmt.Errorf("Error creating CDN account: no product packages found for %s", CdnCategoryCode)
+	}
+
+	productItems, err := services.GetProductPackageService(sess).
+		Id(*pkgs[0].Id).
+		Mask(StoragePackageItemMask).
+		GetItems()
+	if err != nil {
+		return fmt.Errorf("Error creating CDN account: %s", err)
+	}
+
+	// The CDN is not bound to a datacenter, so the standard price is used.
+	prices, err := selectStorageItemPrices(productItems, []storagePriceSelector{
+		{CdnCategoryCode, func(item datatypes.Product_Item, price datatypes.Product_Item_Price) bool {
+			return true
+		}},
+	}, datatypes.Location_Datacenter{})
+	if err != nil {
+		return fmt.Errorf("Error creating CDN account: %s", err)
+	}
+
+	log.Println("[INFO] Creating CDN account")
+
+	receipt, err := services.GetProductOrderService(sess).
+		PlaceOrder(&datatypes.Container_Product_Order_Network_ContentDelivery_Account{
+			Container_Product_Order: datatypes.Container_Product_Order{
+				PackageId: pkgs[0].Id,
+				Prices:    prices,
+				Quantity:  sl.Int(1),
+			},
+		}, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of CDN account: %s", err)
+	}
+
+	billingOrderItem, err := WaitForOrderCompletion(&receipt, meta)
+	if err != nil {
+		return fmt.Errorf("Error waiting for CDN account order (%d) to complete: %s", *receipt.OrderId, err)
+	}
+
+	accounts, err := services.GetAccountService(sess).
+		Mask("id").
+		Filter(filter.Path("cdnAccounts.billingItem.id").Eq(*billingOrderItem.BillingItem.Id).Build()).
+		GetCdnAccounts()
+	if err != nil {
+		return fmt.Errorf("Error finding created CDN account: %s", err)
+	}
+
+	if len(accounts) != 1 {
+		return fmt.Errorf("Expected one CDN account, found %d", len(accounts))
+	}
+
+	d.SetId(fmt.Sprintf("%d", *accounts[0].Id))
+	log.Printf("[INFO] CDN account ID: %s", d.Id())
+
+	return resourceSoftLayerCdnAccountUpdate(d, meta)
+}
+
+func resourceSoftLayerCdnAccountRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	accountId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	service := services.GetNetworkContentDeliveryAccountService(sess).Id(accountId)
+
+	account, err := service.Mask("id,cdnAccountName,cdnAccountNote,logEnabledFlag,status[name]").GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving CDN account: %s", err)
+	}
+
+	d.Set("name", sl.Get(account.CdnAccountName, ""))
+	d.Set("note", sl.Get(account.CdnAccountNote, ""))
+	d.Set("logging_enabled", sl.Get(account.LogEnabledFlag, "") == "Y")
+
+	if account.Status != nil {
+		d.Set("status", sl.Get(account.Status.Name, ""))
+	}
+
+	originPullUrl, err := service.GetOriginPullUrl()
+	if err != nil {
+		return fmt.Errorf("Error retrieving CDN origin pull URL: %s", err)
+	}
+
+	d.Set("origin_pull_url", originPullUrl)
+
+	directories, err := service.GetTokenAuthenticationDirectories()
+	if err != nil {
+		return fmt.Errorf("Error retrieving CDN token authentication directories: %s", err)
+	}
+
+	names := make([]string, 0, len(directories))
+	for _, directory := range directories {
+		if directory.Name != nil {
+			names = append(names, *directory.Name)
+		}
+	}
+
+	d.Set("token_authentication_directories", names)
+
+	return nil
+}
+
+func resourceSoftLayerCdnAccountUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	accountId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	service := services.GetNetworkContentDeliveryAccountService(sess).Id(accountId)
+
+	if d.HasChange("note") {
+		_, err = service.UpdateNote(sl.String(d.Get("note").(string)))
+		if err != nil {
+			return fmt.Errorf("Error updating CDN account note: %s", err)
+		}
+	}
+
+	if d.HasChange("logging_enabled") {
+		if d.Get("logging_enabled").(bool) {
+			_, err = service.EnableLogging()
+		} else {
+			_, err = service.DisableLogging()
+		}
+		if err != nil {
+			return fmt.Errorf("Error updating CDN account logging: %s", err)
+		}
+	}
+
+	if d.HasChange("token_authentication_directories") {
+		o, n := d.GetChange("token_authentication_directories")
+		oldDirectories, newDirectories := o.(*schema.Set), n.(*schema.Set)
+
+		for _, directory := range oldDirectories.Difference(newDirectories).List() {
+			log.Printf("[INFO] Removing CDN token authentication directory: %s", directory)
+			_, err = service.RemoveAuthenticationDirectory(sl.String(directory.(string)), sl.String(CdnMediaType))
+			if err != nil {
+				return fmt.Errorf("Error removing CDN token authentication directory: %s", err)
+			}
+		}
+
+		for _, directory := range newDirectories.Difference(oldDirectories).List() {
+			log.Printf("[INFO] Adding CDN token authentication directory: %s", directory)
+			_, err = service.CreateTokenAuthenticationDirectory(sl.String(directory.(string)), sl.String(CdnMediaType))
+			if err != nil {
+				return fmt.Errorf("Error adding CDN token authentication directory: %s", err)
+			}
+		}
+	}
+
+	return resourceSoftLayerCdnAccountRead(d, meta)
+}
+
+func resourceSoftLayerCdnAccountDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	accountId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	billingItem, err := services.GetNetworkContentDeliveryAccountService(sess).Id(accountId).GetBillingItem()
+	if err != nil {
+		return fmt.Errorf("Error deleting CDN account: %s", err)
+	}
+
+	if billingItem.Id == nil {
+		return fmt.Errorf("Error deleting CDN account: no billing item found for CDN account %d", accountId)
+	}
+
+	log.Printf("[INFO] Cancelling CDN account: %d", accountId)
+	_, err = services.GetBillingItemService(sess).Id(*billingItem.Id).CancelService()
+	if err != nil {
+		return fmt.Errorf("Error deleting CDN account: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerCdnAccountExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+
+	accountId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	result, err := services.GetNetworkContentDeliveryAccountService(sess).Id(accountId).Mask("id").GetObject()
+	return result.Id != nil && err == nil && *result.Id == accountId, nil
+}
+
+// parseCdnId parses the ID of CDN resources which belong to a CDN account, e.g. origin pull mappings. The
+// ID is the CDN account ID and the ID of the resource, separated by a colon.
+func parseCdnId(id string) (int, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return 0, "", fmt.Errorf("Invalid ID, must be <CDN account ID>:<ID>: %s", id)
+	}
+
+	accountId, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", fmt.Errorf("Not a valid CDN account ID, must be an integer: %s", err)
+	}
+
+	return accountId, parts[1], nil
+}
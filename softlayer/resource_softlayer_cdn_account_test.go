@@ -0,0 +1,81 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerCdnAccount_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerCdnAccountDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerCdnAccountConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"softlayer_cdn_account.testacc_cdn", "name"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_cdn_account.testacc_cdn", "origin_pull_url"),
+					resource.TestCheckResourceAttr(
+						"softlayer_cdn_account.testacc_cdn", "note", "terraform test"),
+					resource.TestCheckResourceAttr(
+						"softlayer_cdn_account.testacc_cdn", "logging_enabled", "false"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerCdnAccountConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_cdn_account.testacc_cdn", "note", "terraform test updated"),
+					resource.TestCheckResourceAttr(
+						"softlayer_cdn_account.testacc_cdn", "logging_enabled", "true"),
+					resource.TestCheckResourceAttr(
+						"softlayer_cdn_account.testacc_cdn", "token_authentication_directories.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerCdnAccountDestroy(s *terraform.State) error {
+	service := services.GetNetworkContentDeliveryAccountService(testAccProvider.Meta().(*session.Session))
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_cdn_account" {
+			continue
+		}
+
+		accountId, _ := strconv.Atoi(rs.Primary.ID)
+
+		billingItem, err := service.Id(accountId).GetBillingItem()
+
+		if err == nil && billingItem.Id != nil {
+			return fmt.Errorf("CDN account %d still exists", accountId)
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckSoftLayerCdnAccountConfig_basic = `
+resource "softlayer_cdn_account" "testacc_cdn" {
+    note = "terraform test"
+}
+`
+
+const testAccCheckSoftLayerCdnAccountConfig_updated = `
+resource "softlayer_cdn_account" "testacc_cdn" {
+    note = "terraform test updated"
+    logging_enabled = true
+    token_authentication_directories = ["/secure"]
+}
+`
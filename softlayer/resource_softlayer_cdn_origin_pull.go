@@ -0,0 +1,223 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func resourceSoftLayerCdnOriginPull() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSoftLayerCdnOriginPullCreate,
+		Read:   resourceSoftLayerCdnOriginPullRead,
+		Delete: resourceSoftLayerCdnOriginPullDelete,
+		Exists: resourceSoftLayerCdnOriginPullExists,
+
+		// Origin pull mappings can't be edited, they are replaced instead.
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"origin_host": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"cname": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"protocol": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "http",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					protocol := v.(string)
+					if protocol != "http" && protocol != "https" {
+						errors = append(errors, fmt.Errorf(
+							"Invalid protocol: protocol should be 'http' or 'https'"))
+					}
+					return
+				},
+			},
+		},
+	}
+}
+
+// getCdnOriginPullMapping returns the origin pull mapping with the given ID, or nil if the mapping was
+// removed.
+func getCdnOriginPullMapping(sess *session.Session, accountId int, mappingId string) (
+	*datatypes.Container_Network_ContentDelivery_OriginPull_Mapping, error) {
+	mappings, err := services.GetNetworkContentDeliveryAccountService(sess).
+		Id(accountId).
+		GetOriginPullMappingInformation()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mapping := range mappings {
+		if mapping.Id != nil && *mapping.Id == mappingId {
+			return &mapping, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// The new mapping of an account is found by comparing the mappings before and after it is created, so
+// mappings of the same account are created one at a time.
+var (
+	cdnOriginPullMutexes      = map[int]*sync.Mutex{}
+	cdnOriginPullMutexesMutex sync.Mutex
+)
+
+func getCdnOriginPullMutex(accountId int) *sync.Mutex {
+	cdnOriginPullMutexesMutex.Lock()
+	defer cdnOriginPullMutexesMutex.Unlock()
+
+	if _, ok := cdnOriginPullMutexes[accountId]; !ok {
+		cdnOriginPullMutexes[accountId] = &sync.Mutex{}
+	}
+
+	return cdnOriginPullMutexes[accountId]
+}
+
+// matchCdnOriginPullMapping returns true if a mapping has the origin, the protocol and the CNAME of the given
+// mapping. SoftLayer generates a CNAME if none is given, so the CNAME only matters if the template has one.
+func matchCdnOriginPullMapping(mapping datatypes.Container_Network_ContentDelivery_OriginPull_Mapping,
+	template datatypes.Container_Network_ContentDelivery_OriginPull_Mapping) bool {
+	return sl.Get(mapping.OriginUrl, "") == sl.Get(template.OriginUrl, "") &&
+		sl.Get(mapping.IsSecureContent, false) == sl.Get(template.IsSecureContent, false) &&
+		(template.Cname == nil || strings.EqualFold(sl.Get(mapping.Cname, "").(string), *template.Cname))
+}
+
+func resourceSoftLayerCdnOriginPullCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	accountId := d.Get("account_id").(int)
+	service := services.GetNetworkContentDeliveryAccountService(sess).Id(accountId)
+
+	mutex := getCdnOriginPullMutex(accountId)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	mapping := datatypes.Container_Network_ContentDelivery_OriginPull_Mapping{
+		OriginUrl:       sl.String(d.Get("origin_host").(string)),
+		MediaType:       sl.String(CdnMediaType),
+		IsSecureContent: sl.Bool(d.Get("protocol").(string) == "https"),
+	}
+
+	if cname, ok := d.GetOk("cname"); ok {
+		mapping.Cname = sl.String(cname.(string))
+	}
+
+	existing, err := service.GetOriginPullMappingInformation()
+	if err != nil {
+		return fmt.Errorf("Error retrieving CDN origin pull mappings: %s", err)
+	}
+
+	log.Printf("[INFO] Creating CDN origin pull mapping for %s", *mapping.OriginUrl)
+
+	_, err = service.CreateOriginPullMapping(&mapping)
+	if err != nil {
+		return fmt.Errorf("Error creating CDN origin pull mapping: %s", err)
+	}
+
+	// createOriginPullMapping doesn't return the mapping, so it's the new mapping with the given settings.
+	existingIds := map[string]bool{}
+	for _, m := range existing {
+		existingIds[sl.Get(m.Id, "").(string)] = true
+	}
+
+	mappings, err := service.GetOriginPullMappingInformation()
+	if err != nil {
+		return fmt.Errorf("Error retrieving CDN origin pull mappings: %s", err)
+	}
+
+	for _, m := range mappings {
+		if m.Id != nil && !existingIds[*m.Id] && matchCdnOriginPullMapping(m, mapping) {
+			d.SetId(fmt.Sprintf("%d:%s", accountId, *m.Id))
+			log.Printf("[INFO] CDN origin pull mapping ID: %s", d.Id())
+			return resourceSoftLayerCdnOriginPullRead(d, meta)
+		}
+	}
+
+	return fmt.Errorf("Error finding created CDN origin pull mapping for %s", *mapping.OriginUrl)
+}
+
+func resourceSoftLayerCdnOriginPullRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	accountId, mappingId, err := parseCdnId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	mapping, err := getCdnOriginPullMapping(sess, accountId, mappingId)
+	if err != nil {
+		return fmt.Errorf("Error retrieving CDN origin pull mapping: %s", err)
+	}
+
+	// The mapping was removed in the portal.
+	if mapping == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("account_id", accountId)
+	d.Set("origin_host", sl.Get(mapping.OriginUrl, ""))
+	d.Set("cname", sl.Get(mapping.Cname, ""))
+
+	if sl.Get(mapping.IsSecureContent, false).(bool) {
+		d.Set("protocol", "https")
+	} else {
+		d.Set("protocol", "http")
+	}
+
+	return nil
+}
+
+func resourceSoftLayerCdnOriginPullDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	accountId, mappingId, err := parseCdnId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting CDN origin pull mapping: %s", d.Id())
+	_, err = services.GetNetworkContentDeliveryAccountService(sess).
+		Id(accountId).
+		DeleteOriginPullRule(sl.String(mappingId))
+	if err != nil {
+		return fmt.Errorf("Error deleting CDN origin pull mapping: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerCdnOriginPullExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+
+	accountId, mappingId, err := parseCdnId(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	mapping, err := getCdnOriginPullMapping(sess, accountId, mappingId)
+	return mapping != nil && err == nil, nil
+}
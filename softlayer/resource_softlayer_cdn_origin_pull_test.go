@@ -0,0 +1,82 @@
+package softlayer
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func TestAccSoftLayerCdnOriginPull_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerCdnOriginPullConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_cdn_origin_pull.assets", "origin_host", "assets.terraformuat.softlayer.com"),
+					resource.TestCheckResourceAttr(
+						"softlayer_cdn_origin_pull.assets", "protocol", "http"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerCdnOriginPullConfig_https,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_cdn_origin_pull.assets", "protocol", "https"),
+				),
+			},
+		},
+	})
+}
+
+func TestMatchCdnOriginPullMapping(t *testing.T) {
+	template := datatypes.Container_Network_ContentDelivery_OriginPull_Mapping{
+		OriginUrl:       sl.String("assets.example.com"),
+		IsSecureContent: sl.Bool(false),
+	}
+
+	mapping := template
+	mapping.Id = sl.String("1")
+	mapping.Cname = sl.String("generated.example.com")
+	if !matchCdnOriginPullMapping(mapping, template) {
+		t.Error("Expected a mapping with a generated CNAME to match")
+	}
+
+	mapping.IsSecureContent = sl.Bool(true)
+	if matchCdnOriginPullMapping(mapping, template) {
+		t.Error("Expected a mapping with another protocol not to match")
+	}
+
+	template.Cname = sl.String("cdn.example.com")
+	mapping = template
+	mapping.Cname = sl.String("other.example.com")
+	if matchCdnOriginPullMapping(mapping, template) {
+		t.Error("Expected a mapping with another CNAME not to match")
+	}
+}
+
+const testAccCheckSoftLayerCdnOriginPullConfig_basic = `
+resource "softlayer_cdn_account" "testacc_cdn" {
+}
+
+resource "softlayer_cdn_origin_pull" "assets" {
+    account_id = "${softlayer_cdn_account.testacc_cdn.id}"
+    origin_host = "assets.terraformuat.softlayer.com"
+}
+`
+
+const testAccCheckSoftLayerCdnOriginPullConfig_https = `
+resource "softlayer_cdn_account" "testacc_cdn" {
+}
+
+resource "softlayer_cdn_origin_pull" "assets" {
+    account_id = "${softlayer_cdn_account.testacc_cdn.id}"
+    origin_host = "assets.terraformuat.softlayer.com"
+    protocol = "https"
+}
+`
@@ -0,0 +1,164 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func resourceSoftLayerCdnToken() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSoftLayerCdnTokenCreate,
+		Read:   resourceSoftLayerCdnTokenRead,
+		Delete: resourceSoftLayerCdnTokenDelete,
+		Exists: resourceSoftLayerCdnTokenExists,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"client_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"referrer": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"token": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+// getCdnToken returns the managed token of a CDN account, or nil if the token was revoked.
+func getCdnToken(sess *session.Session, accountId int, token string) (
+	*datatypes.Network_ContentDelivery_Authentication_Token, error) {
+	tokens, err := services.GetNetworkContentDeliveryAuthenticationTokenService(sess).
+		GetAllManagedTokens(sl.Int(accountId))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range tokens {
+		if t.Token != nil && *t.Token == token {
+			return &t, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func resourceSoftLayerCdnTokenCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	accountId := d.Get("account_id").(int)
+
+	template := datatypes.Network_ContentDelivery_Authentication_Token{
+		CdnAccountId: sl.Int(accountId),
+		Name:         sl.String(d.Get("name").(string)),
+	}
+
+	if clientIp, ok := d.GetOk("client_ip"); ok {
+		template.ClientIp = sl.String(clientIp.(string))
+	}
+
+	if referrer, ok := d.GetOk("referrer"); ok {
+		template.Referrer = sl.String(referrer.(string))
+	}
+
+	log.Printf("[INFO] Creating CDN token %s for account %d", *template.Name, accountId)
+
+	token, err := services.GetNetworkContentDeliveryAuthenticationTokenService(sess).CreateObject(&template)
+	if err != nil {
+		return fmt.Errorf("Error creating CDN token: %s", err)
+	}
+
+	if token.Token == nil {
+		return fmt.Errorf("Error creating CDN token: no token returned")
+	}
+
+	// The token is secret, so it is kept in the token attribute and not in the ID.
+	d.SetId(fmt.Sprintf("%d:%s", accountId, *template.Name))
+	d.Set("token", *token.Token)
+
+	return resourceSoftLayerCdnTokenRead(d, meta)
+}
+
+func resourceSoftLayerCdnTokenRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	accountId, _, err := parseCdnId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	token, err := getCdnToken(sess, accountId, d.Get("token").(string))
+	if err != nil {
+		return fmt.Errorf("Error retrieving CDN token: %s", err)
+	}
+
+	// The token was revoked in the portal.
+	if token == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("account_id", accountId)
+	d.Set("name", sl.Get(token.Name, ""))
+	d.Set("client_ip", sl.Get(token.ClientIp, ""))
+	d.Set("referrer", sl.Get(token.Referrer, ""))
+
+	return nil
+}
+
+func resourceSoftLayerCdnTokenDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	accountId, name, err := parseCdnId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Revoking CDN token %s of account %d", name, accountId)
+	_, err = services.GetNetworkContentDeliveryAuthenticationTokenService(sess).
+		RevokeManagedToken(sl.Int(accountId), sl.String(d.Get("token").(string)))
+	if err != nil {
+		return fmt.Errorf("Error revoking CDN token: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerCdnTokenExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+
+	accountId, _, err := parseCdnId(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	token, err := getCdnToken(sess, accountId, d.Get("token").(string))
+	return token != nil && err == nil, nil
+}
@@ -0,0 +1,37 @@
+package softlayer
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSoftLayerCdnToken_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerCdnTokenConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_cdn_token.web", "name", "web"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_cdn_token.web", "token"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckSoftLayerCdnTokenConfig_basic = `
+resource "softlayer_cdn_account" "testacc_cdn" {
+    token_authentication_directories = ["/secure"]
+}
+
+resource "softlayer_cdn_token" "web" {
+    account_id = "${softlayer_cdn_account.testacc_cdn.id}"
+    name = "web"
+    referrer = "terraformuat.softlayer.com"
+}
+`
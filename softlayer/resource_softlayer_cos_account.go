@@ -0,0 +1,212 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	CosPackageCategoryCode = "cloud_object_storage"
+)
+
+func resourceSoftLayerCosAccount() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerCosAccountCreate,
+		Read:     resourceSoftLayerCosAccountRead,
+		Delete:   resourceSoftLayerCosAccountDelete,
+		Exists:   resourceSoftLayerCosAccountExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"endpoints": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"location": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceSoftLayerCosAccountCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	pkgs, err := services.GetProductPackageService(sess).
+		Mask("id").
+		Filter(filter.Build(
+			filter.Path("categories.categoryCode").Eq(CosPackageCategoryCode),
+			filter.Path("statusCode").Eq("ACTIVE"),
+		)).
+		GetAllObjects()
+	if err != nil {
+		return fmt.Errorf("Error creating Cloud Object Storage account: %s", err)
+	}
+
+	if len(pkgs) == 0 {
+		return fmt.Errorf("Error creating Cloud Object Storage account: no product packages found for %s",
+			CosPackageCategoryCode)
+	}
+
+	productItems, err := services.GetProductPackageService(sess).
+		Id(*pkgs[0].Id).
+		Mask(StoragePackageItemMask).
+		GetItems()
+	if err != nil {
+		return fmt.Errorf("Error creating Cloud Object Storage account: %s", err)
+	}
+
+	// Cloud Object Storage is not bound to a datacenter, so the standard price is used.
+	prices, err := selectStorageItemPrices(productItems, []storagePriceSelector{
+		{CosPackageCategoryCode, func(item datatypes.Product_Item, price datatypes.Product_Item_Price) bool {
+			return true
+		}},
+	}, datatypes.Location_Datacenter{})
+	if err != nil {
+		return fmt.Errorf("Error creating Cloud Object Storage account: %s", err)
+	}
+
+	log.Println("[INFO] Creating Cloud Object Storage account")
+
+	receipt, err := services.GetProductOrderService(sess).PlaceOrder(&datatypes.Container_Product_Order{
+		PackageId: pkgs[0].Id,
+		Prices:    prices,
+		Quantity:  sl.Int(1),
+	}, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of Cloud Object Storage account: %s", err)
+	}
+
+	billingOrderItem, err := WaitForOrderCompletion(&receipt, meta)
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for Cloud Object Storage account order (%d) to complete: %s", *receipt.OrderId, err)
+	}
+
+	accounts, err := services.GetAccountService(sess).
+		Mask("id").
+		Filter(filter.Path("hubNetworkStorage.billingItem.id").Eq(*billingOrderItem.BillingItem.Id).Build()).
+		GetHubNetworkStorage()
+	if err != nil {
+		return fmt.Errorf("Error finding created Cloud Object Storage account: %s", err)
+	}
+
+	if len(accounts) != 1 {
+		return fmt.Errorf("Expected one Cloud Object Storage account, found %d", len(accounts))
+	}
+
+	d.SetId(fmt.Sprintf("%d", *accounts[0].Id))
+	log.Printf("[INFO] Cloud Object Storage account ID: %s", d.Id())
+
+	return resourceSoftLayerCosAccountRead(d, meta)
+}
+
+func resourceSoftLayerCosAccountRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	accountId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	service := services.GetNetworkStorageHubCleversafeAccountService(sess).Id(accountId)
+
+	account, err := service.Mask("id,username").GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving Cloud Object Storage account: %s", err)
+	}
+
+	d.Set("name", sl.Get(account.Username, ""))
+
+	endpoints, err := service.GetEndpoints()
+	if err != nil {
+		return fmt.Errorf("Error retrieving Cloud Object Storage endpoints: %s", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		result = append(result, map[string]interface{}{
+			"region":   sl.Get(endpoint.Region, ""),
+			"location": sl.Get(endpoint.Location, ""),
+			"type":     sl.Get(endpoint.Type, ""),
+			"url":      sl.Get(endpoint.Url, ""),
+		})
+	}
+
+	d.Set("endpoints", result)
+
+	return nil
+}
+
+func resourceSoftLayerCosAccountDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	accountId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	billingItem, err := services.GetNetworkStorageHubCleversafeAccountService(sess).Id(accountId).GetBillingItem()
+	if err != nil {
+		return fmt.Errorf("Error deleting Cloud Object Storage account: %s", err)
+	}
+
+	if billingItem.Id == nil {
+		return fmt.Errorf("Error deleting Cloud Object Storage account: no billing item found for account %d",
+			accountId)
+	}
+
+	log.Printf("[INFO] Cancelling Cloud Object Storage account: %d", accountId)
+	_, err = services.GetBillingItemService(sess).Id(*billingItem.Id).CancelService()
+	if err != nil {
+		return fmt.Errorf("Error deleting Cloud Object Storage account: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerCosAccountExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+
+	accountId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	result, err := services.GetNetworkStorageHubCleversafeAccountService(sess).
+		Id(accountId).
+		Mask("id,billingItem[id]").
+		GetObject()
+	return result.Id != nil && err == nil && *result.Id == accountId && result.BillingItem != nil, nil
+}
@@ -0,0 +1,58 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerCosAccount_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerCosAccountDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerCosAccountConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"softlayer_cos_account.testacc_cos", "name"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_cos_account.testacc_cos", "endpoints.0.url"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerCosAccountDestroy(s *terraform.State) error {
+	sess := testAccProvider.Meta().(*session.Session)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_cos_account" {
+			continue
+		}
+
+		accountId, _ := strconv.Atoi(rs.Primary.ID)
+
+		account, err := services.GetNetworkStorageHubCleversafeAccountService(sess).
+			Id(accountId).
+			Mask("id,billingItem[id]").
+			GetObject()
+		if err == nil && account.BillingItem != nil {
+			return fmt.Errorf("Cloud Object Storage account %d still exists", accountId)
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckSoftLayerCosAccountConfig_basic = `
+resource "softlayer_cos_account" "testacc_cos" {
+}
+`
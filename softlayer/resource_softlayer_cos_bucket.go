@@ -0,0 +1,198 @@
+package softlayer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+var cosStorageClasses = []string{"standard", "vault", "cold", "flex"}
+
+func resourceSoftLayerCosBucket() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSoftLayerCosBucketCreate,
+		Read:   resourceSoftLayerCosBucketRead,
+		Update: resourceSoftLayerCosBucketUpdate,
+		Delete: resourceSoftLayerCosBucketDelete,
+		Exists: resourceSoftLayerCosBucketExists,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					name := v.(string)
+					if !regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`).MatchString(name) {
+						errors = append(errors, fmt.Errorf(
+							"Invalid name: name should be 3-63 lower case letters, digits, '.' or '-'"))
+					}
+					return
+				},
+			},
+
+			"region": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					region := v.(string)
+					if _, ok := cosEndpoints[region]; !ok {
+						errors = append(errors, fmt.Errorf(
+							"Invalid region: region should be 'us', 'eu', 'us-south' or 'us-east'"))
+					}
+					return
+				},
+			},
+
+			"storage_class": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "standard",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					storageClass := v.(string)
+					for _, class := range cosStorageClasses {
+						if storageClass == class {
+							return
+						}
+					}
+					errors = append(errors, fmt.Errorf(
+						"Invalid storage_class: storage_class should be 'standard', 'vault', 'cold' or 'flex'"))
+					return
+				},
+			},
+
+			"access_key_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"secret_access_key": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+// cosBucketConfiguration is the body of a create bucket request. The location constraint is the region and
+// the storage class of the bucket, e.g. us-south-vault.
+type cosBucketConfiguration struct {
+	XMLName            xml.Name `xml:"CreateBucketConfiguration"`
+	LocationConstraint string   `xml:"LocationConstraint"`
+}
+
+type cosBucketLocation struct {
+	XMLName            xml.Name `xml:"LocationConstraint"`
+	LocationConstraint string   `xml:",chardata"`
+}
+
+func getCosBucketClient(d *schema.ResourceData) (*s3Client, error) {
+	endpoint, err := getCosEndpoint(d.Get("region").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3Client{
+		endpoint:  endpoint,
+		accessKey: d.Get("access_key_id").(string),
+		secretKey: d.Get("secret_access_key").(string),
+	}, nil
+}
+
+func resourceSoftLayerCosBucketCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getCosBucketClient(d)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+
+	body, err := xml.Marshal(cosBucketConfiguration{
+		LocationConstraint: d.Get("region").(string) + "-" + d.Get("storage_class").(string),
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Creating Cloud Object Storage bucket: %s", name)
+	_, err = client.Do("PUT", name, "", body)
+	if err != nil {
+		return fmt.Errorf("Error creating Cloud Object Storage bucket: %s", err)
+	}
+
+	d.SetId(name)
+
+	return resourceSoftLayerCosBucketRead(d, meta)
+}
+
+func resourceSoftLayerCosBucketRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := getCosBucketClient(d)
+	if err != nil {
+		return err
+	}
+
+	body, err := client.Do("GET", d.Id(), "location", nil)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Cloud Object Storage bucket: %s", err)
+	}
+
+	location := cosBucketLocation{}
+	err = xml.Unmarshal(body, &location)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Cloud Object Storage bucket location: %s", err)
+	}
+
+	d.Set("name", d.Id())
+
+	// The storage class is the last part of the location constraint.
+	if i := strings.LastIndex(location.LocationConstraint, "-"); i != -1 {
+		d.Set("region", location.LocationConstraint[:i])
+		d.Set("storage_class", location.LocationConstraint[i+1:])
+	}
+
+	return nil
+}
+
+// The credentials only configure the client of the bucket, so changing them, e.g. to rotate a key, doesn't
+// change the bucket.
+func resourceSoftLayerCosBucketUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceSoftLayerCosBucketRead(d, meta)
+}
+
+func resourceSoftLayerCosBucketDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := getCosBucketClient(d)
+	if err != nil {
+		return err
+	}
+
+	// Buckets which still have objects can't be deleted.
+	log.Printf("[INFO] Deleting Cloud Object Storage bucket: %s", d.Id())
+	_, err = client.Do("DELETE", d.Id(), "", nil)
+	if err != nil && !isS3NotFound(err) {
+		return fmt.Errorf("Error deleting Cloud Object Storage bucket: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerCosBucketExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	client, err := getCosBucketClient(d)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = client.Do("HEAD", d.Id(), "", nil)
+	if isS3NotFound(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
@@ -0,0 +1,44 @@
+package softlayer
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSoftLayerCosBucket_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerCosBucketConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_cos_bucket.testacc_bucket", "name", "terraform-testacc-bucket"),
+					resource.TestCheckResourceAttr(
+						"softlayer_cos_bucket.testacc_bucket", "region", "us"),
+					resource.TestCheckResourceAttr(
+						"softlayer_cos_bucket.testacc_bucket", "storage_class", "vault"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckSoftLayerCosBucketConfig_basic = `
+resource "softlayer_cos_account" "testacc_cos" {
+}
+
+resource "softlayer_cos_credential" "testacc_cos" {
+    account_id = "${softlayer_cos_account.testacc_cos.id}"
+}
+
+resource "softlayer_cos_bucket" "testacc_bucket" {
+    name = "terraform-testacc-bucket"
+    region = "us"
+    storage_class = "vault"
+    access_key_id = "${softlayer_cos_credential.testacc_cos.access_key_id}"
+    secret_access_key = "${softlayer_cos_credential.testacc_cos.secret_access_key}"
+}
+`
@@ -0,0 +1,167 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func resourceSoftLayerCosCredential() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSoftLayerCosCredentialCreate,
+		Read:   resourceSoftLayerCosCredentialRead,
+		Delete: resourceSoftLayerCosCredentialDelete,
+		Exists: resourceSoftLayerCosCredentialExists,
+
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"access_key_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"secret_access_key": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+// The new credential of an account is found by comparing the credentials before and after it is created, so
+// credentials of the same account are created one at a time.
+var (
+	cosCredentialMutexes      = map[int]*sync.Mutex{}
+	cosCredentialMutexesMutex sync.Mutex
+)
+
+func getCosCredentialMutex(accountId int) *sync.Mutex {
+	cosCredentialMutexesMutex.Lock()
+	defer cosCredentialMutexesMutex.Unlock()
+
+	if _, ok := cosCredentialMutexes[accountId]; !ok {
+		cosCredentialMutexes[accountId] = &sync.Mutex{}
+	}
+
+	return cosCredentialMutexes[accountId]
+}
+
+func resourceSoftLayerCosCredentialCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	accountId := d.Get("account_id").(int)
+	service := services.GetNetworkStorageHubCleversafeAccountService(sess).Id(accountId)
+
+	mutex := getCosCredentialMutex(accountId)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	existing, err := service.GetCredentials()
+	if err != nil {
+		return fmt.Errorf("Error retrieving Cloud Object Storage credentials: %s", err)
+	}
+
+	log.Printf("[INFO] Creating Cloud Object Storage credential for account %d", accountId)
+
+	// credentialCreate returns all credentials of the account, the new one is the one which didn't exist.
+	credentials, err := service.CredentialCreate()
+	if err != nil {
+		return fmt.Errorf("Error creating Cloud Object Storage credential: %s", err)
+	}
+
+	existingIds := make([]int, 0, len(existing))
+	for _, credential := range existing {
+		existingIds = append(existingIds, *credential.Id)
+	}
+
+	for _, credential := range credentials {
+		if credential.Id != nil && !contains(existingIds, *credential.Id) {
+			d.SetId(fmt.Sprintf("%d", *credential.Id))
+			log.Printf("[INFO] Cloud Object Storage credential ID: %s", d.Id())
+			return resourceSoftLayerCosCredentialRead(d, meta)
+		}
+	}
+
+	return fmt.Errorf("Error creating Cloud Object Storage credential: the new credential wasn't returned")
+}
+
+func getCosCredential(d *schema.ResourceData, meta interface{}) (*datatypes.Network_Storage_Credential, error) {
+	sess := meta.(*session.Session)
+
+	credentialId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	credentials, err := services.GetNetworkStorageHubCleversafeAccountService(sess).
+		Id(d.Get("account_id").(int)).
+		GetCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, credential := range credentials {
+		if credential.Id != nil && *credential.Id == credentialId {
+			return &credential, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func resourceSoftLayerCosCredentialRead(d *schema.ResourceData, meta interface{}) error {
+	credential, err := getCosCredential(d, meta)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Cloud Object Storage credential: %s", err)
+	}
+
+	if credential == nil {
+		return fmt.Errorf("Error retrieving Cloud Object Storage credential: credential %s not found", d.Id())
+	}
+
+	d.Set("access_key_id", sl.Get(credential.Username, ""))
+	d.Set("secret_access_key", sl.Get(credential.Password, ""))
+
+	return nil
+}
+
+func resourceSoftLayerCosCredentialDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	credentialId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	log.Printf("[INFO] Deleting Cloud Object Storage credential: %d", credentialId)
+	_, err = services.GetNetworkStorageHubCleversafeAccountService(sess).
+		Id(d.Get("account_id").(int)).
+		CredentialDelete(&datatypes.Network_Storage_Credential{Id: sl.Int(credentialId)})
+	if err != nil {
+		return fmt.Errorf("Error deleting Cloud Object Storage credential: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerCosCredentialExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	credential, err := getCosCredential(d, meta)
+	if err != nil {
+		return false, err
+	}
+
+	return credential != nil, nil
+}
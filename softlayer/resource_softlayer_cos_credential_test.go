@@ -0,0 +1,34 @@
+package softlayer
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSoftLayerCosCredential_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerCosCredentialConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"softlayer_cos_credential.testacc_cos", "access_key_id"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_cos_credential.testacc_cos", "secret_access_key"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckSoftLayerCosCredentialConfig_basic = `
+resource "softlayer_cos_account" "testacc_cos" {
+}
+
+resource "softlayer_cos_credential" "testacc_cos" {
+    account_id = "${softlayer_cos_account.testacc_cos.id}"
+}
+`
@@ -47,6 +47,27 @@ func resourceSoftLayerDnsDomain() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+
+			// The records of the domain are imported from the zone file. Records which were imported before
+			// and aren't in the zone file anymore are deleted. Other records of the domain, e.g. of
+			// softlayer_dns_domain_record, are left alone.
+			"zone_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// The IDs of the records which belong to the zone file.
+			"zone_file_record_ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Set:      schema.HashInt,
+			},
+
+			"zone_file_contents": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -81,6 +102,15 @@ func resourceSoftLayerDnsDomainCreate(d *schema.ResourceData, meta interface{})
 	d.SetId(strconv.Itoa(id))
 	log.Printf("[INFO] Created Dns Domain: %d", id)
 
+	if zoneFile, ok := d.GetOk("zone_file"); ok {
+		ids, err := importDnsZoneFile(sess, id, d.Get("name").(string), d.Get("target").(string),
+			zoneFile.(string), map[int]bool{})
+		if err != nil {
+			return err
+		}
+		d.Set("zone_file_record_ids", ids)
+	}
+
 	// read remote state
 	return resourceSoftLayerDnsDomainRead(d, meta)
 }
@@ -112,6 +142,29 @@ func resourceSoftLayerDnsDomainRead(d *schema.ResourceData, meta interface{}) er
 		}
 	}
 
+	zoneFileContents, err := service.Id(dnsId).GetZoneFileContents()
+	if err != nil {
+		return fmt.Errorf("Error retrieving zone file of Dns Domain %d: %s", dnsId, err)
+	}
+
+	d.Set("zone_file_contents", zoneFileContents)
+
+	// Records of the zone file which were changed outside of Terraform are noticed by comparing them with the
+	// zone file. If they differ, the zone file is cleared in the state, so the next plan imports it again.
+	if zoneFile, ok := d.GetOk("zone_file"); ok {
+		creates, updates, deletes, err := diffDnsZoneFile(sess, dnsId, *dns_domain.Name, d.Get("target").(string),
+			zoneFile.(string), getDnsZoneFileRecordIds(d))
+		if err != nil {
+			return err
+		}
+
+		if len(creates) > 0 || len(updates) > 0 || len(deletes) > 0 {
+			log.Printf("[INFO] The records of Dns Domain %d differ from its zone file: %d missing, %d changed "+
+				"and %d additional records", dnsId, len(creates), len(updates), len(deletes))
+			d.Set("zone_file", "")
+		}
+	}
+
 	return nil
 }
 
@@ -120,45 +173,66 @@ func resourceSoftLayerDnsDomainUpdate(d *schema.ResourceData, meta interface{})
 	sess := meta.(*session.Session)
 	domainId, _ := strconv.Atoi(d.Id())
 
-	if !d.HasChange("target") { // target is the only editable field
-		return nil
-	}
+	if d.HasChange("target") {
+		newTarget := d.Get("target").(string)
 
-	newTarget := d.Get("target").(string)
+		// retrieve domain state
+		domainService := services.GetDnsDomainService(sess)
+		domain, err := domainService.Id(domainId).Mask(
+			"id,name,updateDate,resourceRecords",
+		).GetObject()
+		if err != nil {
+			return fmt.Errorf("Error retrieving DNS resource %d: %s", domainId, err)
+		}
 
-	// retrieve domain state
-	domainService := services.GetDnsDomainService(sess)
-	domain, err := domainService.Id(domainId).Mask(
-		"id,name,updateDate,resourceRecords",
-	).GetObject()
-	if err != nil {
-		return fmt.Errorf("Error retrieving DNS resource %d: %s", domainId, err)
-	}
+		// find a record with host @; that will have the current target.
+		var record datatypes.Dns_Domain_ResourceRecord
+		for _, record = range domain.ResourceRecords {
+			if *record.Type == "a" && *record.Host == "@" {
+				break
+			}
+		}
 
-	// find a record with host @; that will have the current target.
-	var record datatypes.Dns_Domain_ResourceRecord
-	for _, record = range domain.ResourceRecords {
-		if *record.Type == "a" && *record.Host == "@" {
-			break
+		if record.Id == nil {
+			return fmt.Errorf("Could not find DNS target record for domain %s (%d)",
+				sl.Get(domain.Name), sl.Get(domain.Id))
 		}
-	}
 
-	if record.Id == nil {
-		return fmt.Errorf("Could not find DNS target record for domain %s (%d)",
-			sl.Get(domain.Name), sl.Get(domain.Id))
-	}
+		record.Data = sl.String(newTarget)
 
-	record.Data = sl.String(newTarget)
+		_, err = services.GetDnsDomainResourceRecordService(sess).
+			Id(*record.Id).EditObject(&record)
 
-	_, err = services.GetDnsDomainResourceRecordService(sess).
-		Id(*record.Id).EditObject(&record)
+		if err != nil {
+			return fmt.Errorf("Error editing DNS target record for domain %s (%d): %s",
+				sl.Get(domain.Name), sl.Get(domain.Id), err)
+		}
+	}
 
-	if err != nil {
-		return fmt.Errorf("Error editing DNS target record for domain %s (%d): %s",
-			sl.Get(domain.Name), sl.Get(domain.Id), err)
+	// Removing the zone file leaves the records of the domain as they are.
+	if d.HasChange("zone_file") {
+		if zoneFile, ok := d.GetOk("zone_file"); ok {
+			ids, err := importDnsZoneFile(sess, domainId, d.Get("name").(string), d.Get("target").(string),
+				zoneFile.(string), getDnsZoneFileRecordIds(d))
+			if err != nil {
+				return err
+			}
+			d.Set("zone_file_record_ids", ids)
+		} else {
+			d.Set("zone_file_record_ids", []int{})
+		}
 	}
 
-	return nil
+	return resourceSoftLayerDnsDomainRead(d, meta)
+}
+
+// getDnsZoneFileRecordIds returns the IDs of the records which belong to the zone file of a domain.
+func getDnsZoneFileRecordIds(d *schema.ResourceData) map[int]bool {
+	ids := map[int]bool{}
+	for _, id := range d.Get("zone_file_record_ids").(*schema.Set).List() {
+		ids[id.(int)] = true
+	}
+	return ids
 }
 
 func resourceSoftLayerDnsDomainDelete(d *schema.ResourceData, meta interface{}) error {
@@ -3,6 +3,7 @@ package softlayer
 import (
 	"fmt"
 	"log"
+	"net"
 	"regexp"
 	"strconv"
 	"strings"
@@ -15,17 +16,145 @@ import (
 )
 
 var allowedDomainRecordTypes = []string{
-	"a", "aaaa", "cname", "mx", "ptr", "spf", "srv", "txt",
+	"a", "aaaa", "caa", "cname", "mx", "ns", "ptr", "spf", "srv", "txt",
 }
-var ipv6Regexp *regexp.Regexp
 var upcaseRegexp *regexp.Regexp
+var hostnameLabelRegexp *regexp.Regexp
+var caaDataRegexp *regexp.Regexp
+
+// The maximum length of a character string in a TXT record. Longer data is split into several strings.
+const dnsTxtStringLength = 255
+
+// The tags of CAA records, from RFC 6844.
+var caaTags = []string{"issue", "issuewild", "iodef"}
 
 func init() {
-	ipv6Regexp, _ = regexp.Compile(
-		"[a-zA-Z0-9]{4}:[a-zA-Z0-9]{4}:[a-zA-Z0-9]{4}:[a-zA-Z0-9]{4}:" +
-			"[a-zA-Z0-9]{4}:[a-zA-Z0-9]{4}:[a-zA-Z0-9]{4}:[a-zA-Z0-9]{4}",
-	)
 	upcaseRegexp, _ = regexp.Compile("[A-Z]")
+	hostnameLabelRegexp, _ = regexp.Compile("^[a-zA-Z0-9_]([a-zA-Z0-9_-]{0,61}[a-zA-Z0-9_])?$")
+	caaDataRegexp, _ = regexp.Compile(`^(\d+)\s+([a-zA-Z0-9]+)\s+"([^"]*)"$`)
+}
+
+// isDnsFqdn returns true for a fully qualified domain name, e.g. mail.example.com or mail.example.com.
+func isDnsFqdn(name string) bool {
+	name = strings.TrimSuffix(name, ".")
+	labels := strings.Split(name, ".")
+	if len(name) > 253 || len(labels) < 2 {
+		return false
+	}
+
+	for _, label := range labels {
+		if !hostnameLabelRegexp.MatchString(label) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateDnsDomainRecord checks the data and the type specific fields of a record before it is created or
+// updated, which are only checked by the API otherwise. The checks depend on the type of the record, so they
+// can't be done by the ValidateFuncs of the fields.
+func validateDnsDomainRecord(d *schema.ResourceData) error {
+	recordType := d.Get("type").(string)
+	data := d.Get("data").(string)
+
+	switch recordType {
+	case "a":
+		if ip := net.ParseIP(data); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("The data of an a record must be an IPv4 address: %s", data)
+		}
+	case "aaaa":
+		if ip := net.ParseIP(data); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("The data of an aaaa record must be an IPv6 address: %s", data)
+		}
+	case "cname", "mx", "ns":
+		if !isDnsFqdn(data) {
+			return fmt.Errorf("The data of a %s record must be a fully qualified domain name: %s", recordType, data)
+		}
+	case "srv":
+		if !isDnsFqdn(data) {
+			return fmt.Errorf("The data of a srv record must be a fully qualified domain name: %s", data)
+		}
+		for _, field := range []string{"service", "protocol"} {
+			if _, ok := d.GetOk(field); !ok {
+				return fmt.Errorf("%s is required for srv records", field)
+			}
+		}
+		if _, ok := d.GetOk("port"); !ok {
+			return fmt.Errorf("port is required for srv records")
+		}
+	case "caa":
+		return validateCaaData(data)
+	}
+
+	return nil
+}
+
+// validateDnsRecordRange returns a ValidateFunc which checks that a number is between min and max.
+func validateDnsRecordRange(min int, max int) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		if value := v.(int); value < min || value > max {
+			errors = append(errors, fmt.Errorf("%s must be between %d and %d: %d", k, min, max, value))
+		}
+		return
+	}
+}
+
+// validateDnsSrvName checks the service or the protocol of a SRV record, which starts with an underscore,
+// e.g. _sip or _tcp.
+func validateDnsSrvName(v interface{}, k string) (ws []string, errors []error) {
+	if value := v.(string); !strings.HasPrefix(value, "_") || len(value) < 2 {
+		errors = append(errors, fmt.Errorf("%s must start with an underscore, e.g. _sip: %s", k, value))
+	}
+	return
+}
+
+// validateCaaData checks the data of a CAA record, e.g. 0 issue "letsencrypt.org".
+func validateCaaData(data string) error {
+	match := caaDataRegexp.FindStringSubmatch(data)
+	if match == nil {
+		return fmt.Errorf("The data of a caa record must be <flags> <tag> \"<value>\": %s", data)
+	}
+
+	if flags, err := strconv.Atoi(match[1]); err != nil || flags > 255 {
+		return fmt.Errorf("The flags of a caa record must be between 0 and 255: %s", match[1])
+	}
+
+	for _, tag := range caaTags {
+		if strings.ToLower(match[2]) == tag {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("The tag of a caa record must be one of %s: %s", strings.Join(caaTags, ", "), match[2])
+}
+
+// splitDnsTxtData splits the data of a TXT record into strings of at most 255 characters, e.g. a DKIM key.
+// SoftLayer quotes the data of TXT records, so the strings are separated by '" "'.
+func splitDnsTxtData(data string) string {
+	if len(data) <= dnsTxtStringLength {
+		return data
+	}
+
+	parts := []string{}
+	for len(data) > dnsTxtStringLength {
+		parts = append(parts, data[:dnsTxtStringLength])
+		data = data[dnsTxtStringLength:]
+	}
+
+	return strings.Join(append(parts, data), `" "`)
+}
+
+// joinDnsTxtData reverses splitDnsTxtData.
+func joinDnsTxtData(data string) string {
+	parts := strings.Split(data, `" "`)
+	for _, part := range parts[:len(parts)-1] {
+		if len(part) != dnsTxtStringLength {
+			return data
+		}
+	}
+
+	return strings.Join(parts, "")
 }
 
 func resourceSoftLayerDnsDomainRecord() *schema.Resource {
@@ -47,7 +176,7 @@ func resourceSoftLayerDnsDomainRecord() *schema.Resource {
 				Required: true,
 				ValidateFunc: func(val interface{}, field string) (warnings []string, errors []error) {
 					value := val.(string)
-					if ipv6Regexp.MatchString(value) && upcaseRegexp.MatchString(value) {
+					if ip := net.ParseIP(value); ip != nil && ip.To4() == nil && upcaseRegexp.MatchString(value) {
 						errors = append(
 							errors,
 							fmt.Errorf(
@@ -77,9 +206,10 @@ func resourceSoftLayerDnsDomainRecord() *schema.Resource {
 			},
 
 			"mx_priority": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				Computed: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateDnsRecordRange(0, 65535),
 			},
 
 			"refresh": {
@@ -137,29 +267,34 @@ func resourceSoftLayerDnsDomainRecord() *schema.Resource {
 			},
 
 			"service": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDnsSrvName,
 			},
 
 			"protocol": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDnsSrvName,
 			},
 
 			"port": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateDnsRecordRange(1, 65535),
 			},
 
 			"priority": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				Computed: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateDnsRecordRange(0, 65535),
 			},
 
 			"weight": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateDnsRecordRange(0, 65535),
 			},
 		},
 	}
@@ -171,6 +306,10 @@ func resourceSoftLayerDnsDomainRecordCreate(d *schema.ResourceData, meta interfa
 	sess := meta.(*session.Session)
 	service := services.GetDnsDomainResourceRecordService(sess)
 
+	if err := validateDnsDomainRecord(d); err != nil {
+		return err
+	}
+
 	opts := datatypes.Dns_Domain_ResourceRecord{
 		Data:     sl.String(d.Get("data").(string)),
 		DomainId: sl.Int(d.Get("domain_id").(int)),
@@ -187,8 +326,9 @@ func resourceSoftLayerDnsDomainRecordCreate(d *schema.ResourceData, meta interfa
 		opts.Minimum = sl.Int(minimum.(int))
 	}
 
-	if mxPriority, ok := d.GetOk("mx_priority"); ok {
-		opts.MxPriority = sl.Int(mxPriority.(int))
+	// The priority of mx records is always sent, as 0 is a valid priority.
+	if *opts.Type == "mx" {
+		opts.MxPriority = sl.Int(d.Get("mx_priority").(int))
 	}
 
 	if refresh, ok := d.GetOk("refresh"); ok {
@@ -203,6 +343,10 @@ func resourceSoftLayerDnsDomainRecordCreate(d *schema.ResourceData, meta interfa
 		opts.Retry = sl.Int(retry.(int))
 	}
 
+	if *opts.Type == "txt" {
+		opts.Data = sl.String(splitDnsTxtData(*opts.Data))
+	}
+
 	optsSrv := datatypes.Dns_Domain_ResourceRecord_SrvType{
 		Dns_Domain_ResourceRecord: opts,
 	}
@@ -274,7 +418,11 @@ func resourceSoftLayerDnsDomainRecordRead(d *schema.ResourceData, meta interface
 	}
 
 	// Required fields
-	d.Set("data", *result.Data)
+	if *result.Type == "txt" {
+		d.Set("data", joinDnsTxtData(*result.Data))
+	} else {
+		d.Set("data", *result.Data)
+	}
 	d.Set("domain_id", *result.DomainId)
 	d.Set("host", *result.Host)
 	d.Set("type", *result.Type)
@@ -305,6 +453,10 @@ func resourceSoftLayerDnsDomainRecordUpdate(d *schema.ResourceData, meta interfa
 	sess := meta.(*session.Session)
 	recordId, _ := strconv.Atoi(d.Id())
 
+	if err := validateDnsDomainRecord(d); err != nil {
+		return err
+	}
+
 	service := services.GetDnsDomainResourceRecordService(sess)
 	record, err := service.Id(recordId).GetObject()
 	if err != nil {
@@ -315,6 +467,9 @@ func resourceSoftLayerDnsDomainRecordUpdate(d *schema.ResourceData, meta interfa
 
 	if data, ok := d.GetOk("data"); ok && d.HasChange("data") {
 		record.Data = sl.String(data.(string))
+		if recordType == "txt" {
+			record.Data = sl.String(splitDnsTxtData(*record.Data))
+		}
 	}
 
 	if domain_id, ok := d.GetOk("domain_id"); ok && d.HasChange("domain_id") {
@@ -337,8 +492,8 @@ func resourceSoftLayerDnsDomainRecordUpdate(d *schema.ResourceData, meta interfa
 		record.Minimum = sl.Int(minimum_ttl.(int))
 	}
 
-	if mx_priority, ok := d.GetOk("mx_priority"); ok && d.HasChange("mx_priority") {
-		record.MxPriority = sl.Int(mx_priority.(int))
+	if d.HasChange("mx_priority") {
+		record.MxPriority = sl.Int(d.Get("mx_priority").(int))
 	}
 
 	if refresh, ok := d.GetOk("refresh"); ok && d.HasChange("refresh") {
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -60,6 +61,8 @@ func TestAccSoftLayerDnsDomainRecord_Types(t *testing.T) {
 					testAccCheckSoftLayerDnsDomainRecordExists("softlayer_dns_domain_record.recordAAAA", &dns_domain_record),
 					testAccCheckSoftLayerDnsDomainRecordExists("softlayer_dns_domain_record.recordCNAME", &dns_domain_record),
 					testAccCheckSoftLayerDnsDomainRecordExists("softlayer_dns_domain_record.recordMX", &dns_domain_record),
+					testAccCheckSoftLayerDnsDomainRecordExists("softlayer_dns_domain_record.recordNS", &dns_domain_record),
+					testAccCheckSoftLayerDnsDomainRecordExists("softlayer_dns_domain_record.recordCAA", &dns_domain_record),
 					testAccCheckSoftLayerDnsDomainRecordExists("softlayer_dns_domain_record.recordSPF", &dns_domain_record),
 					testAccCheckSoftLayerDnsDomainRecordExists("softlayer_dns_domain_record.recordTXT", &dns_domain_record),
 					testAccCheckSoftLayerDnsDomainRecordExists("softlayer_dns_domain_record.recordSRV", &dns_domain_record),
@@ -78,6 +81,87 @@ func TestAccSoftLayerDnsDomainRecord_Types(t *testing.T) {
 	})
 }
 
+func TestIsDnsFqdn(t *testing.T) {
+	cases := map[string]bool{
+		"mail.example.com":      true,
+		"mail.example.com.":     true,
+		"_sip._tcp.example.com": true,
+		"mail":                  false,
+		"mail..example.com":     false,
+		"-mail.example.com":     false,
+		"mail.example.com-":     false,
+		"10.0.0.1 10.0.0.2":     false,
+		"":                      false,
+	}
+
+	for name, expected := range cases {
+		if isDnsFqdn(name) != expected {
+			t.Errorf("isDnsFqdn(%q) should be %t", name, expected)
+		}
+	}
+}
+
+func TestValidateCaaData(t *testing.T) {
+	valid := []string{`0 issue "letsencrypt.org"`, `128 issuewild ";"`, `0 iodef "mailto:security@example.com"`}
+	for _, data := range valid {
+		if err := validateCaaData(data); err != nil {
+			t.Errorf("validateCaaData(%q): %s", data, err)
+		}
+	}
+
+	invalid := []string{`0 issue letsencrypt.org`, `256 issue "letsencrypt.org"`, `0 unknown "x"`, `issue "x"`}
+	for _, data := range invalid {
+		if err := validateCaaData(data); err == nil {
+			t.Errorf("validateCaaData(%q): expected an error", data)
+		}
+	}
+}
+
+func TestValidateDnsSrvFields(t *testing.T) {
+	for _, value := range []string{"_sip", "_tcp"} {
+		if _, errs := validateDnsSrvName(value, "service"); len(errs) > 0 {
+			t.Errorf("validateDnsSrvName(%q): %s", value, errs)
+		}
+	}
+
+	for _, value := range []string{"", "_", "sip"} {
+		if _, errs := validateDnsSrvName(value, "service"); len(errs) == 0 {
+			t.Errorf("validateDnsSrvName(%q): expected an error", value)
+		}
+	}
+
+	validatePort := validateDnsRecordRange(1, 65535)
+	for port, valid := range map[int]bool{0: false, 1: true, 5060: true, 65535: true, 65536: false} {
+		if _, errs := validatePort(port, "port"); (len(errs) == 0) != valid {
+			t.Errorf("validateDnsRecordRange(1, 65535)(%d) should be %t", port, valid)
+		}
+	}
+}
+
+func TestSplitDnsTxtData(t *testing.T) {
+	short := "v=spf1 mx -all"
+	if splitDnsTxtData(short) != short || joinDnsTxtData(short) != short {
+		t.Errorf("Short TXT data should not be split")
+	}
+
+	long := strings.Repeat("a", 255) + strings.Repeat("b", 255) + "c"
+	split := splitDnsTxtData(long)
+
+	if split != strings.Repeat("a", 255)+`" "`+strings.Repeat("b", 255)+`" "c` {
+		t.Errorf("Unexpected split TXT data: %s", split)
+	}
+
+	if joinDnsTxtData(split) != long {
+		t.Errorf("Joined TXT data doesn't match: %s", joinDnsTxtData(split))
+	}
+
+	// Quoted strings which weren't split by splitDnsTxtData are kept.
+	quoted := `first" "second`
+	if joinDnsTxtData(quoted) != quoted {
+		t.Errorf("Unexpected joined TXT data: %s", joinDnsTxtData(quoted))
+	}
+}
+
 func testAccCheckSoftLayerDnsDomainRecordExists(n string, dns_domain_record *datatypes.Dns_Domain_ResourceRecord) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -162,15 +246,34 @@ resource "softlayer_dns_domain_record" "recordCNAME" {
     type = "cname"
 }
 
+resource "softlayer_dns_domain_record" "recordNS" {
+    data = "ns1.example.com"
+    domain_id = "${softlayer_dns_domain.test_dns_domain_record_types.id}"
+    host = "delegated"
+    responsible_person = "user@softlayer.com"
+    ttl = 900
+    type = "ns"
+}
+
 resource "softlayer_dns_domain_record" "recordMX" {
     data = "email.example.com"
     domain_id = "${softlayer_dns_domain.test_dns_domain_record_types.id}"
     host = "hosta-mx.com"
+    mx_priority = 10
     responsible_person = "user@softlayer.com"
     ttl = 900
     type = "mx"
 }
 
+resource "softlayer_dns_domain_record" "recordCAA" {
+    data = "0 issue \"letsencrypt.org\""
+    domain_id = "${softlayer_dns_domain.test_dns_domain_record_types.id}"
+    host = "@"
+    responsible_person = "user@softlayer.com"
+    ttl = 900
+    type = "caa"
+}
+
 resource "softlayer_dns_domain_record" "recordSPF" {
     data = "v=spf1 mx:mail.example.org ~all"
     domain_id = "${softlayer_dns_domain.test_dns_domain_record_types.id}"
@@ -0,0 +1,505 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const dnsDomainRegistrationMask = "id,name,lockedFlag,expireDate," +
+	"domainRegistrationStatus[keyName],registrantVerificationStatus[keyName]"
+
+// softlayer_dns_domain_registration manages a domain registration which was bought through SoftLayer.
+// Registrations are ordered and renewed outside of Terraform, so creating the resource adopts the existing
+// registration of the domain, and deleting it only removes it from the state.
+func resourceSoftLayerDnsDomainRegistration() *schema.Resource {
+	return &schema.Resource{
+		Exists:   resourceSoftLayerDnsDomainRegistrationExists,
+		Create:   resourceSoftLayerDnsDomainRegistrationCreate,
+		Read:     resourceSoftLayerDnsDomainRegistrationRead,
+		Update:   resourceSoftLayerDnsDomainRegistrationUpdate,
+		Delete:   resourceSoftLayerDnsDomainRegistrationDelete,
+		Importer: &schema.ResourceImporter{},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(v interface{}) string {
+					return strings.ToLower(v.(string))
+				},
+			},
+
+			// The nameservers of the domain, in lower case and without a trailing dot.
+			"name_servers": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			// The transfer lock of the domain. If it isn't configured, the lock is left alone.
+			"locked": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+
+			// Contacts of types which aren't configured are left alone.
+			"contact": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+								switch v.(string) {
+								case "owner", "admin", "billing", "tech":
+								default:
+									errors = append(errors,
+										fmt.Errorf("Invalid contact type: must be 'owner', 'admin', 'billing' or 'tech'"))
+								}
+								return
+							},
+						},
+
+						"first_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"last_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"organization_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"address1": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"address2": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"address3": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"city": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"state": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"postal_code": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						// The two letter country code, e.g. US.
+						"country": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"email": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						// The phone number in the format +<country code>.<number>, e.g. +1.2145551234.
+						"phone": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"fax": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"expire_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"registrant_verification_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// The date when the domain is suspended if the registrant isn't verified.
+			"verification_deadline_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// normalizeDnsNameserver returns a nameserver in lower case and without a trailing dot.
+func normalizeDnsNameserver(nameserver string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(nameserver)), ".")
+}
+
+// diffDnsNameservers returns the nameservers which are added and removed to change the current nameservers
+// of a domain to the wanted ones.
+func diffDnsNameservers(current []string, wanted []string) ([]string, []string) {
+	currentSet := map[string]bool{}
+	for _, nameserver := range current {
+		currentSet[normalizeDnsNameserver(nameserver)] = true
+	}
+
+	wantedSet := map[string]bool{}
+	for _, nameserver := range wanted {
+		wantedSet[normalizeDnsNameserver(nameserver)] = true
+	}
+
+	adds := []string{}
+	for nameserver := range wantedSet {
+		if !currentSet[nameserver] {
+			adds = append(adds, nameserver)
+		}
+	}
+
+	removes := []string{}
+	for nameserver := range currentSet {
+		if !wantedSet[nameserver] {
+			removes = append(removes, nameserver)
+		}
+	}
+
+	sort.Strings(adds)
+	sort.Strings(removes)
+
+	return adds, removes
+}
+
+// flattenDnsDomainRegistrationNameservers returns the names of the nameservers of a domain.
+func flattenDnsDomainRegistrationNameservers(nameservers []datatypes.Container_Dns_Domain_Registration_Nameserver) []string {
+	names := []string{}
+	for _, nameserver := range nameservers {
+		for _, entry := range nameserver.Nameservers {
+			if entry.Name != nil {
+				names = append(names, normalizeDnsNameserver(*entry.Name))
+			}
+		}
+	}
+
+	return names
+}
+
+func expandDnsDomainRegistrationContact(contactMap map[string]interface{}) datatypes.Container_Dns_Domain_Registration_Contact {
+	return datatypes.Container_Dns_Domain_Registration_Contact{
+		Type:             sl.String(contactMap["type"].(string)),
+		FirstName:        sl.String(contactMap["first_name"].(string)),
+		LastName:         sl.String(contactMap["last_name"].(string)),
+		OrganizationName: sl.String(contactMap["organization_name"].(string)),
+		Address1:         sl.String(contactMap["address1"].(string)),
+		Address2:         sl.String(contactMap["address2"].(string)),
+		Address3:         sl.String(contactMap["address3"].(string)),
+		City:             sl.String(contactMap["city"].(string)),
+		State:            sl.String(contactMap["state"].(string)),
+		PostalCode:       sl.String(contactMap["postal_code"].(string)),
+		Country:          sl.String(contactMap["country"].(string)),
+		Email:            sl.String(contactMap["email"].(string)),
+		Phone:            sl.String(contactMap["phone"].(string)),
+		Fax:              sl.String(contactMap["fax"].(string)),
+	}
+}
+
+func flattenDnsDomainRegistrationContact(contact datatypes.Container_Dns_Domain_Registration_Contact) map[string]interface{} {
+	return map[string]interface{}{
+		"type":              strings.ToLower(sl.Get(contact.Type, "").(string)),
+		"first_name":        sl.Get(contact.FirstName, ""),
+		"last_name":         sl.Get(contact.LastName, ""),
+		"organization_name": sl.Get(contact.OrganizationName, ""),
+		"address1":          sl.Get(contact.Address1, ""),
+		"address2":          sl.Get(contact.Address2, ""),
+		"address3":          sl.Get(contact.Address3, ""),
+		"city":              sl.Get(contact.City, ""),
+		"state":             sl.Get(contact.State, ""),
+		"postal_code":       sl.Get(contact.PostalCode, ""),
+		"country":           sl.Get(contact.Country, ""),
+		"email":             sl.Get(contact.Email, ""),
+		"phone":             sl.Get(contact.Phone, ""),
+		"fax":               sl.Get(contact.Fax, ""),
+	}
+}
+
+// updateDnsDomainRegistration changes the nameservers, the transfer lock and the contacts of a domain
+// registration which differ from the configuration. When create is false, only changed arguments are
+// compared.
+func updateDnsDomainRegistration(d *schema.ResourceData, sess *session.Session, registrationId int, create bool) error {
+	service := services.GetDnsDomainRegistrationService(sess).Id(registrationId)
+	name := d.Get("name").(string)
+
+	if nameservers, ok := d.GetOk("name_servers"); ok && (create || d.HasChange("name_servers")) {
+		current, err := service.GetDomainNameservers()
+		if err != nil {
+			return fmt.Errorf("Error retrieving nameservers of Dns Domain Registration %s: %s", name, err)
+		}
+
+		wanted := []string{}
+		for _, nameserver := range nameservers.(*schema.Set).List() {
+			wanted = append(wanted, nameserver.(string))
+		}
+
+		// Nameservers are added before they are removed, as a domain always needs nameservers.
+		adds, removes := diffDnsNameservers(flattenDnsDomainRegistrationNameservers(current), wanted)
+
+		if len(adds) > 0 {
+			log.Printf("[INFO] Adding nameservers to Dns Domain Registration %s: %v", name, adds)
+
+			_, err = service.AddNameserversToDomain(adds)
+			if err != nil {
+				return fmt.Errorf("Error adding nameservers to Dns Domain Registration %s: %s", name, err)
+			}
+		}
+
+		if len(removes) > 0 {
+			log.Printf("[INFO] Removing nameservers from Dns Domain Registration %s: %v", name, removes)
+
+			_, err = service.RemoveNameserversFromDomain(removes)
+			if err != nil {
+				return fmt.Errorf("Error removing nameservers from Dns Domain Registration %s: %s", name, err)
+			}
+		}
+	}
+
+	// An unset lock can't be told apart from false, so an adopted registration is only locked, and is
+	// unlocked once the configuration differs from its state.
+	_, lock := d.GetOk("locked")
+	if (create && lock) || (!create && d.HasChange("locked")) {
+		registration, err := service.Mask("lockedFlag").GetObject()
+		if err != nil {
+			return fmt.Errorf("Error retrieving Dns Domain Registration %s: %s", name, err)
+		}
+
+		locked := d.Get("locked").(bool)
+		if locked != (sl.Get(registration.LockedFlag, 0) == 1) {
+			if locked {
+				log.Printf("[INFO] Locking Dns Domain Registration %s", name)
+				_, err = service.LockDomain()
+			} else {
+				log.Printf("[INFO] Unlocking Dns Domain Registration %s", name)
+				_, err = service.UnlockDomain()
+			}
+
+			if err != nil {
+				return fmt.Errorf("Error changing the transfer lock of Dns Domain Registration %s: %s", name, err)
+			}
+		}
+	}
+
+	if contacts, ok := d.GetOk("contact"); ok && (create || d.HasChange("contact")) {
+		information, err := service.GetDomainInformation()
+		if err != nil {
+			return fmt.Errorf("Error retrieving contacts of Dns Domain Registration %s: %s", name, err)
+		}
+
+		current := map[string]map[string]interface{}{}
+		for _, contact := range information.Contacts {
+			contactMap := flattenDnsDomainRegistrationContact(contact)
+			current[contactMap["type"].(string)] = contactMap
+		}
+
+		types := map[string]bool{}
+		for _, c := range contacts.(*schema.Set).List() {
+			contactMap := c.(map[string]interface{})
+			contactType := contactMap["type"].(string)
+
+			if types[contactType] {
+				return fmt.Errorf("Dns Domain Registration %s has more than one %s contact", name, contactType)
+			}
+			types[contactType] = true
+
+			// Unchanged contacts aren't modified, as modifying the owner may require the registrant to be
+			// verified again.
+			if reflect.DeepEqual(current[contactType], contactMap) {
+				continue
+			}
+
+			log.Printf("[INFO] Modifying %s contact of Dns Domain Registration %s", contactType, name)
+
+			contact := expandDnsDomainRegistrationContact(contactMap)
+			_, err = service.ModifyContact(&contact)
+			if err != nil {
+				return fmt.Errorf("Error modifying %s contact of Dns Domain Registration %s: %s", contactType, name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceSoftLayerDnsDomainRegistrationCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	name := strings.ToLower(d.Get("name").(string))
+
+	registrations, err := services.GetAccountService(sess).
+		Filter(filter.Path("domainRegistrations.name").Eq(name).Build()).
+		Mask("id,name").
+		GetDomainRegistrations()
+	if err != nil {
+		return fmt.Errorf("Error retrieving Dns Domain Registrations: %s", err)
+	}
+
+	if len(registrations) == 0 {
+		return fmt.Errorf("No Dns Domain Registration found for %s, domains must be registered through SoftLayer first", name)
+	}
+
+	registrationId := *registrations[0].Id
+	d.SetId(strconv.Itoa(registrationId))
+	log.Printf("[INFO] Managing Dns Domain Registration %s: %d", name, registrationId)
+
+	err = updateDnsDomainRegistration(d, sess, registrationId, true)
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+
+	return resourceSoftLayerDnsDomainRegistrationRead(d, meta)
+}
+
+func resourceSoftLayerDnsDomainRegistrationRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	registrationId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	service := services.GetDnsDomainRegistrationService(sess).Id(registrationId)
+
+	registration, err := service.Mask(dnsDomainRegistrationMask).GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving Dns Domain Registration %d: %s", registrationId, err)
+	}
+
+	d.Set("name", sl.Get(registration.Name, ""))
+	d.Set("locked", sl.Get(registration.LockedFlag, 0) == 1)
+
+	if registration.ExpireDate != nil {
+		d.Set("expire_date", registration.ExpireDate.String())
+	}
+
+	if registration.DomainRegistrationStatus != nil {
+		d.Set("status", sl.Get(registration.DomainRegistrationStatus.KeyName, ""))
+	}
+
+	if registration.RegistrantVerificationStatus != nil {
+		d.Set("registrant_verification_status", sl.Get(registration.RegistrantVerificationStatus.KeyName, ""))
+	}
+
+	verification, err := service.GetRegistrantVerificationStatusDetail()
+	if err != nil {
+		return fmt.Errorf("Error retrieving registrant verification of Dns Domain Registration %d: %s", registrationId, err)
+	}
+
+	if verification.VerificationDeadlineDate != nil {
+		d.Set("verification_deadline_date", verification.VerificationDeadlineDate.String())
+	} else {
+		d.Set("verification_deadline_date", "")
+	}
+
+	nameservers, err := service.GetDomainNameservers()
+	if err != nil {
+		return fmt.Errorf("Error retrieving nameservers of Dns Domain Registration %d: %s", registrationId, err)
+	}
+
+	d.Set("name_servers", flattenDnsDomainRegistrationNameservers(nameservers))
+
+	information, err := service.GetDomainInformation()
+	if err != nil {
+		return fmt.Errorf("Error retrieving contacts of Dns Domain Registration %d: %s", registrationId, err)
+	}
+
+	// Only the contacts of managed types are read, unless no contacts are managed, e.g. after an import.
+	managed := map[string]bool{}
+	for _, c := range d.Get("contact").(*schema.Set).List() {
+		managed[c.(map[string]interface{})["type"].(string)] = true
+	}
+
+	contacts := []map[string]interface{}{}
+	for _, contact := range information.Contacts {
+		contactMap := flattenDnsDomainRegistrationContact(contact)
+		if len(managed) == 0 || managed[contactMap["type"].(string)] {
+			contacts = append(contacts, contactMap)
+		}
+	}
+
+	d.Set("contact", contacts)
+
+	return nil
+}
+
+func resourceSoftLayerDnsDomainRegistrationUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	registrationId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	err = updateDnsDomainRegistration(d, sess, registrationId, false)
+	if err != nil {
+		return err
+	}
+
+	return resourceSoftLayerDnsDomainRegistrationRead(d, meta)
+}
+
+// The registration can't be cancelled through the API, it stays with the account until it expires.
+func resourceSoftLayerDnsDomainRegistrationDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Removing Dns Domain Registration %s from the state, the registration is kept", d.Id())
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerDnsDomainRegistrationExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+
+	registrationId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	result, err := services.GetDnsDomainRegistrationService(sess).Id(registrationId).Mask("id").GetObject()
+	return err == nil && result.Id != nil && *result.Id == registrationId, nil
+}
@@ -0,0 +1,67 @@
+package softlayer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// The domain must be registered through the SoftLayer account of the test.
+const testAccDnsDomainRegistrationName = "terraform-acc-test.com"
+
+func TestAccSoftLayerDnsDomainRegistration_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSoftLayerDnsDomainRegistrationConfig, "true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_dns_domain_registration.registration", "name", testAccDnsDomainRegistrationName),
+					resource.TestCheckResourceAttr(
+						"softlayer_dns_domain_registration.registration", "locked", "true"),
+					resource.TestCheckResourceAttr(
+						"softlayer_dns_domain_registration.registration", "name_servers.#", "2"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_dns_domain_registration.registration", "expire_date"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_dns_domain_registration.registration", "status"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_dns_domain_registration.registration", "registrant_verification_status"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSoftLayerDnsDomainRegistrationConfig, "false"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_dns_domain_registration.registration", "locked", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestDiffDnsNameservers(t *testing.T) {
+	current := []string{"ns1.softlayer.com", "NS2.softlayer.com.", "ns3.example.com"}
+	wanted := []string{"ns1.softlayer.com.", "ns2.softlayer.com", "ns4.example.com", "NS5.example.com"}
+
+	adds, removes := diffDnsNameservers(current, wanted)
+
+	if fmt.Sprint(adds) != "[ns4.example.com ns5.example.com]" {
+		t.Errorf("Unexpected adds: %v", adds)
+	}
+
+	if fmt.Sprint(removes) != "[ns3.example.com]" {
+		t.Errorf("Unexpected removes: %v", removes)
+	}
+}
+
+var testAccCheckSoftLayerDnsDomainRegistrationConfig = `
+resource "softlayer_dns_domain_registration" "registration" {
+    name = "` + testAccDnsDomainRegistrationName + `"
+    name_servers = ["ns1.softlayer.com", "ns2.softlayer.com"]
+    locked = %s
+}
+`
@@ -68,6 +68,68 @@ func TestAccSoftLayerDnsDomain_Basic(t *testing.T) {
 	})
 }
 
+func TestAccSoftLayerDnsDomain_ZoneFile(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerDnsDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(zoneFileConfig, domainName1, target1, "www IN A 172.16.0.102"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerDnsDomainRecordCount(
+						"softlayer_dns_domain.acceptance_test_dns_domain-zone", "a", 2),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_dns_domain.acceptance_test_dns_domain-zone", "zone_file_contents"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(zoneFileConfig, domainName1, target1,
+					"mail IN A 172.16.0.103\n@ IN MX 10 mail"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerDnsDomainRecordCount(
+						"softlayer_dns_domain.acceptance_test_dns_domain-zone", "a", 2),
+					testAccCheckSoftLayerDnsDomainRecordCount(
+						"softlayer_dns_domain.acceptance_test_dns_domain-zone", "mx", 1),
+					resource.TestCheckResourceAttr(
+						"softlayer_dns_domain.acceptance_test_dns_domain-zone", "target", target1),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerDnsDomainRecordCount(n string, recordType string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		dns_id, _ := strconv.Atoi(rs.Primary.ID)
+
+		records, err := services.GetDnsDomainService(testAccProvider.Meta().(*session.Session)).
+			Id(dns_id).GetResourceRecords()
+		if err != nil {
+			return err
+		}
+
+		found := 0
+		for _, record := range records {
+			if sl.Get(record.Type, "") == recordType {
+				found++
+			}
+		}
+
+		if found != count {
+			return fmt.Errorf("Expected %d %s records, found %d", count, recordType, found)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckSoftLayerDnsDomainDestroy(s *terraform.State) error {
 	service := services.GetDnsDomainService(testAccProvider.Meta().(*session.Session))
 
@@ -204,6 +266,14 @@ resource "softlayer_dns_domain" "acceptance_test_dns_domain-1" {
 }
 `
 
+var zoneFileConfig = `
+resource "softlayer_dns_domain" "acceptance_test_dns_domain-zone" {
+	name = "%s"
+	target = "%s"
+	zone_file = "%s"
+}
+`
+
 var domainName1 = "zxczcxzxc.com"
 var domainName2 = "vbnvnvbnv.com"
 var target1 = "172.16.0.100"
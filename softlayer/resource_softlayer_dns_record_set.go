@@ -0,0 +1,333 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+// softlayer_dns_record_set owns all records of a host and type, of a host, of a type, or of the entire domain.
+// Records in its scope which aren't in the record set are deleted, so records created outside of Terraform
+// are noticed. The SOA and NS records of the domain itself are never part of a record set.
+func resourceSoftLayerDnsRecordSet() *schema.Resource {
+	return &schema.Resource{
+		Exists:   resourceSoftLayerDnsRecordSetExists,
+		Create:   resourceSoftLayerDnsRecordSetCreate,
+		Read:     resourceSoftLayerDnsRecordSetRead,
+		Update:   resourceSoftLayerDnsRecordSetUpdate,
+		Delete:   resourceSoftLayerDnsRecordSetDelete,
+		Importer: &schema.ResourceImporter{},
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// The host of the records which are owned, or all hosts if empty.
+			"host": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			// The type of the records which are owned, or all types if empty.
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDnsRecordSetType,
+			},
+
+			"record": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateDnsRecordSetType,
+						},
+
+						"data": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"ttl": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  86400,
+						},
+
+						"mx_priority": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+
+						"service": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"protocol": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"port": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+
+						"priority": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+
+						"weight": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateDnsRecordSetType only accepts lower case record types, which is how SoftLayer returns them, so the
+// types in the configuration and the state are equal.
+func validateDnsRecordSetType(v interface{}, k string) (ws []string, errors []error) {
+	if value := v.(string); value != strings.ToLower(value) {
+		errors = append(errors, fmt.Errorf("Invalid %s: record types must be lower case, e.g. a or mx: %s", k, value))
+	}
+	return
+}
+
+// parseDnsRecordSetId parses the ID of a record set, which is the domain ID, the host and the type separated
+// by colons, e.g. 1234:www:a or 1234:: for all records of the domain.
+func parseDnsRecordSetId(id string) (int, string, string, error) {
+	parts := strings.SplitN(id, ":", 3)
+	if len(parts) != 3 {
+		return 0, "", "", fmt.Errorf("Invalid ID, must be <domain ID>:<host>:<type>: %s", id)
+	}
+
+	domainId, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", "", fmt.Errorf("Not a valid domain ID, must be an integer: %s", err)
+	}
+
+	return domainId, parts[1], parts[2], nil
+}
+
+// inDnsRecordSetScope returns true if a record belongs to the host and type of a record set.
+func inDnsRecordSetScope(record datatypes.Dns_Domain_ResourceRecord, host string, recordType string) bool {
+	return (host == "" || strings.EqualFold(sl.Get(record.Host, "").(string), host)) &&
+		(recordType == "" || strings.EqualFold(sl.Get(record.Type, "").(string), recordType))
+}
+
+// getDnsRecordSetRecords returns the records of a domain which belong to a record set.
+func getDnsRecordSetRecords(sess *session.Session, domainId int, host string,
+	recordType string) ([]datatypes.Dns_Domain_ResourceRecord, error) {
+	records, err := services.GetDnsDomainService(sess).Id(domainId).Mask(dnsZoneRecordMask).GetResourceRecords()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving records of Dns Domain %d: %s", domainId, err)
+	}
+
+	owned := []datatypes.Dns_Domain_ResourceRecord{}
+	for _, record := range records {
+		if isDnsZoneManagedRecord(record) && inDnsRecordSetScope(record, host, recordType) {
+			owned = append(owned, record)
+		}
+	}
+
+	return owned, nil
+}
+
+// expandDnsRecordSet returns the records of a record set, which must belong to its host and type.
+func expandDnsRecordSet(d *schema.ResourceData) ([]datatypes.Dns_Domain_ResourceRecord, error) {
+	host := d.Get("host").(string)
+	recordType := d.Get("type").(string)
+
+	records := []datatypes.Dns_Domain_ResourceRecord{}
+	for _, r := range d.Get("record").(*schema.Set).List() {
+		recordMap := r.(map[string]interface{})
+
+		record := datatypes.Dns_Domain_ResourceRecord{
+			Host: sl.String(recordMap["host"].(string)),
+			Type: sl.String(strings.ToLower(recordMap["type"].(string))),
+			Data: sl.String(recordMap["data"].(string)),
+			Ttl:  sl.Int(recordMap["ttl"].(int)),
+		}
+
+		if !inDnsRecordSetScope(record, host, recordType) {
+			return nil, fmt.Errorf("The %s record of host %s doesn't belong to the record set", *record.Type, *record.Host)
+		}
+
+		if !isDnsZoneManagedRecord(record) {
+			return nil, fmt.Errorf("The %s records of the domain itself are managed by SoftLayer", *record.Type)
+		}
+
+		switch *record.Type {
+		case "mx":
+			record.MxPriority = sl.Int(recordMap["mx_priority"].(int))
+		case "srv":
+			record.Service = sl.String(recordMap["service"].(string))
+			record.Protocol = sl.String(recordMap["protocol"].(string))
+			record.Port = sl.Int(recordMap["port"].(int))
+			record.Priority = sl.Int(recordMap["priority"].(int))
+			record.Weight = sl.Int(recordMap["weight"].(int))
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// reconcileDnsRecordSet makes the records in the scope of a record set match the record set.
+func reconcileDnsRecordSet(d *schema.ResourceData, sess *session.Session, domainId int) error {
+	host := d.Get("host").(string)
+	recordType := d.Get("type").(string)
+
+	wanted, err := expandDnsRecordSet(d)
+	if err != nil {
+		return err
+	}
+
+	current, err := getDnsRecordSetRecords(sess, domainId, host, recordType)
+	if err != nil {
+		return err
+	}
+
+	creates, updates, deletes := diffDnsZoneRecords(current, wanted)
+
+	log.Printf("[INFO] Updating record set %s of Dns Domain %d: %d new, %d changed and %d deleted records",
+		d.Id(), domainId, len(creates), len(updates), len(deletes))
+
+	return applyDnsRecordChanges(sess, domainId, creates, updates, deletes)
+}
+
+func resourceSoftLayerDnsRecordSetCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	domainId := d.Get("domain_id").(int)
+
+	d.SetId(fmt.Sprintf("%d:%s:%s", domainId, d.Get("host").(string), strings.ToLower(d.Get("type").(string))))
+
+	err := reconcileDnsRecordSet(d, sess, domainId)
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+
+	return resourceSoftLayerDnsRecordSetRead(d, meta)
+}
+
+func resourceSoftLayerDnsRecordSetRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	domainId, host, recordType, err := parseDnsRecordSetId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	records, err := getDnsRecordSetRecords(sess, domainId, host, recordType)
+	if err != nil {
+		return err
+	}
+
+	recordList := make([]map[string]interface{}, 0, len(records))
+	for _, record := range records {
+		recordMap := map[string]interface{}{
+			"host": sl.Get(record.Host, ""),
+			"type": sl.Get(record.Type, ""),
+			"data": sl.Get(record.Data, ""),
+			"ttl":  sl.Get(record.Ttl, 0),
+		}
+
+		switch sl.Get(record.Type, "") {
+		case "txt":
+			recordMap["data"] = joinDnsTxtData(sl.Get(record.Data, "").(string))
+		case "mx":
+			recordMap["mx_priority"] = sl.Get(record.MxPriority, 0)
+		case "srv":
+			recordMap["service"] = sl.Get(record.Service, "")
+			recordMap["protocol"] = sl.Get(record.Protocol, "")
+			recordMap["port"] = sl.Get(record.Port, 0)
+			recordMap["priority"] = sl.Get(record.Priority, 0)
+			recordMap["weight"] = sl.Get(record.Weight, 0)
+		}
+
+		recordList = append(recordList, recordMap)
+	}
+
+	d.Set("domain_id", domainId)
+	d.Set("host", host)
+	d.Set("type", recordType)
+	d.Set("record", recordList)
+
+	return nil
+}
+
+func resourceSoftLayerDnsRecordSetUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	if d.HasChange("record") {
+		err := reconcileDnsRecordSet(d, sess, d.Get("domain_id").(int))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceSoftLayerDnsRecordSetRead(d, meta)
+}
+
+func resourceSoftLayerDnsRecordSetDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	domainId, host, recordType, err := parseDnsRecordSetId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	records, err := getDnsRecordSetRecords(sess, domainId, host, recordType)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting record set %s: %d records", d.Id(), len(records))
+
+	err = applyDnsRecordChanges(sess, domainId, nil, nil, records)
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerDnsRecordSetExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+
+	domainId, _, _, err := parseDnsRecordSetId(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	result, err := services.GetDnsDomainService(sess).Id(domainId).Mask("id").GetObject()
+	return err == nil && result.Id != nil && *result.Id == domainId, nil
+}
@@ -0,0 +1,246 @@
+package softlayer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func TestAccSoftLayerDnsRecordSet_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerDnsRecordSetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerDnsRecordSetConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_dns_record_set.www", "record.#", "2"),
+					testAccCheckSoftLayerDnsRecordSetCount("softlayer_dns_record_set.www", 2),
+				),
+			},
+			{
+				Config: testAccCheckSoftLayerDnsRecordSetConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_dns_record_set.www", "record.#", "1"),
+					testAccCheckSoftLayerDnsRecordSetCount("softlayer_dns_record_set.www", 1),
+				),
+			},
+		},
+	})
+}
+
+func TestParseDnsRecordSetId(t *testing.T) {
+	domainId, host, recordType, err := parseDnsRecordSetId("1234:www:a")
+	if err != nil || domainId != 1234 || host != "www" || recordType != "a" {
+		t.Errorf("Unexpected result: %d, %s, %s, %v", domainId, host, recordType, err)
+	}
+
+	domainId, host, recordType, err = parseDnsRecordSetId("1234::")
+	if err != nil || domainId != 1234 || host != "" || recordType != "" {
+		t.Errorf("Unexpected result: %d, %s, %s, %v", domainId, host, recordType, err)
+	}
+
+	for _, id := range []string{"1234", "abc::", ""} {
+		if _, _, _, err = parseDnsRecordSetId(id); err == nil {
+			t.Errorf("Expected an error for %q", id)
+		}
+	}
+}
+
+func TestInDnsRecordSetScope(t *testing.T) {
+	record := datatypes.Dns_Domain_ResourceRecord{
+		Host: sl.String("www"),
+		Type: sl.String("a"),
+	}
+
+	cases := []struct {
+		host       string
+		recordType string
+		expected   bool
+	}{
+		{"www", "a", true},
+		{"WWW", "A", true},
+		{"www", "", true},
+		{"", "a", true},
+		{"", "", true},
+		{"mail", "a", false},
+		{"www", "mx", false},
+	}
+
+	for _, c := range cases {
+		if inDnsRecordSetScope(record, c.host, c.recordType) != c.expected {
+			t.Errorf("inDnsRecordSetScope(%q, %q): expected %t", c.host, c.recordType, c.expected)
+		}
+	}
+}
+
+func TestExpandDnsRecordSet(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceSoftLayerDnsRecordSet().Schema, map[string]interface{}{
+		"domain_id": 1234,
+		"host":      "@",
+		"record": []interface{}{
+			map[string]interface{}{
+				"host":        "@",
+				"type":        "mx",
+				"data":        "mail.example.com.",
+				"mx_priority": 0,
+			},
+			map[string]interface{}{
+				"host": "@",
+				"type": "a",
+				"data": "10.0.0.1",
+				"ttl":  300,
+			},
+		},
+	})
+
+	records, err := expandDnsRecordSet(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+
+	for _, record := range records {
+		switch *record.Type {
+		case "mx":
+			if record.MxPriority == nil || *record.MxPriority != 0 || *record.Ttl != 86400 {
+				t.Errorf("Unexpected mx record: %v", record)
+			}
+		case "a":
+			if *record.Data != "10.0.0.1" || *record.Ttl != 300 || record.MxPriority != nil {
+				t.Errorf("Unexpected a record: %v", record)
+			}
+		default:
+			t.Errorf("Unexpected record type: %s", *record.Type)
+		}
+	}
+
+	// Records outside the scope of the record set, and the NS records of the domain, are rejected.
+	for _, recordMap := range []map[string]interface{}{
+		{"host": "www", "type": "a", "data": "10.0.0.1"},
+		{"host": "@", "type": "ns", "data": "ns1.softlayer.com."},
+	} {
+		d = schema.TestResourceDataRaw(t, resourceSoftLayerDnsRecordSet().Schema, map[string]interface{}{
+			"domain_id": 1234,
+			"host":      "@",
+			"record":    []interface{}{recordMap},
+		})
+
+		if _, err = expandDnsRecordSet(d); err == nil {
+			t.Errorf("Expected an error for %v", recordMap)
+		}
+	}
+}
+
+func TestValidateDnsRecordSetType(t *testing.T) {
+	if _, errors := validateDnsRecordSetType("mx", "type"); len(errors) != 0 {
+		t.Errorf("Expected a lower case type to be valid: %v", errors)
+	}
+
+	if _, errors := validateDnsRecordSetType("MX", "type"); len(errors) == 0 {
+		t.Error("Expected an upper case type to be rejected")
+	}
+}
+
+func testAccCheckSoftLayerDnsRecordSetCount(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		domainId, host, recordType, err := parseDnsRecordSetId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		records, err := getDnsRecordSetRecords(testAccProvider.Meta().(*session.Session), domainId, host, recordType)
+		if err != nil {
+			return err
+		}
+
+		if len(records) != count {
+			return fmt.Errorf("Expected %d records in record set %s, found %d", count, rs.Primary.ID, len(records))
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckSoftLayerDnsRecordSetDestroy(s *terraform.State) error {
+	sess := testAccProvider.Meta().(*session.Session)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_dns_record_set" {
+			continue
+		}
+
+		domainId, host, recordType, _ := parseDnsRecordSetId(rs.Primary.ID)
+
+		records, err := getDnsRecordSetRecords(sess, domainId, host, recordType)
+		if err == nil && len(records) > 0 {
+			return fmt.Errorf("Record set %s still has %d records", rs.Primary.ID, len(records))
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckSoftLayerDnsRecordSetConfig_basic = `
+resource "softlayer_dns_domain" "test_dns_domain_record_set" {
+	name = "record-set-test.com"
+	target = "172.16.0.100"
+}
+
+resource "softlayer_dns_record_set" "www" {
+	domain_id = "${softlayer_dns_domain.test_dns_domain_record_set.id}"
+	host = "www"
+	type = "a"
+
+	record {
+		host = "www"
+		type = "a"
+		data = "172.16.0.101"
+		ttl = 900
+	}
+
+	record {
+		host = "www"
+		type = "a"
+		data = "172.16.0.102"
+		ttl = 900
+	}
+}
+`
+
+const testAccCheckSoftLayerDnsRecordSetConfig_updated = `
+resource "softlayer_dns_domain" "test_dns_domain_record_set" {
+	name = "record-set-test.com"
+	target = "172.16.0.100"
+}
+
+resource "softlayer_dns_record_set" "www" {
+	domain_id = "${softlayer_dns_domain.test_dns_domain_record_set.id}"
+	host = "www"
+	type = "a"
+
+	record {
+		host = "www"
+		type = "a"
+		data = "172.16.0.102"
+		ttl = 900
+	}
+}
+`
@@ -0,0 +1,189 @@
+package softlayer
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const dnsSecondaryMask = "id,zoneName,masterIpAddress,transferFrequency,statusText,lastUpdate,status[name]"
+
+func resourceSoftLayerDnsSecondary() *schema.Resource {
+	return &schema.Resource{
+		Exists:   resourceSoftLayerDnsSecondaryExists,
+		Create:   resourceSoftLayerDnsSecondaryCreate,
+		Read:     resourceSoftLayerDnsSecondaryRead,
+		Update:   resourceSoftLayerDnsSecondaryUpdate,
+		Delete:   resourceSoftLayerDnsSecondaryDelete,
+		Importer: &schema.ResourceImporter{},
+		Schema: map[string]*schema.Schema{
+			"zone_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"master_ip_address": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// The transfer frequency in minutes.
+			"transfer_frequency": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			// Any change of the trigger transfers the zone from the master immediately, e.g. after the zone
+			// was changed on the master.
+			"transfer_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status_text": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"last_update": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerDnsSecondaryCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetDnsSecondaryService(sess)
+
+	opts := datatypes.Dns_Secondary{
+		ZoneName:          sl.String(d.Get("zone_name").(string)),
+		MasterIpAddress:   sl.String(d.Get("master_ip_address").(string)),
+		TransferFrequency: sl.Int(d.Get("transfer_frequency").(int)),
+	}
+
+	response, err := service.CreateObject(&opts)
+	if err != nil {
+		return fmt.Errorf("Error creating Dns Secondary: %s", err)
+	}
+
+	id := *response.Id
+	d.SetId(strconv.Itoa(id))
+	log.Printf("[INFO] Created Dns Secondary: %d", id)
+
+	return resourceSoftLayerDnsSecondaryRead(d, meta)
+}
+
+func resourceSoftLayerDnsSecondaryRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetDnsSecondaryService(sess)
+
+	secondaryId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	secondary, err := service.Id(secondaryId).Mask(dnsSecondaryMask).GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving Dns Secondary %d: %s", secondaryId, err)
+	}
+
+	d.Set("zone_name", sl.Get(secondary.ZoneName, ""))
+	d.Set("master_ip_address", sl.Get(secondary.MasterIpAddress, ""))
+	d.Set("transfer_frequency", sl.Get(secondary.TransferFrequency, 0))
+	d.Set("status_text", sl.Get(secondary.StatusText, ""))
+
+	if secondary.Status != nil {
+		d.Set("status", sl.Get(secondary.Status.Name, ""))
+	}
+
+	if secondary.LastUpdate != nil {
+		d.Set("last_update", secondary.LastUpdate.String())
+	}
+
+	return nil
+}
+
+func resourceSoftLayerDnsSecondaryUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetDnsSecondaryService(sess)
+
+	secondaryId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	if d.HasChange("master_ip_address") || d.HasChange("transfer_frequency") {
+		opts := datatypes.Dns_Secondary{
+			MasterIpAddress:   sl.String(d.Get("master_ip_address").(string)),
+			TransferFrequency: sl.Int(d.Get("transfer_frequency").(int)),
+		}
+
+		_, err = service.Id(secondaryId).EditObject(&opts)
+		if err != nil {
+			return fmt.Errorf("Error editing Dns Secondary %d: %s", secondaryId, err)
+		}
+	}
+
+	// A new master is transferred from right away, instead of at the next scheduled transfer.
+	if d.HasChange("master_ip_address") || d.HasChange("transfer_trigger") {
+		log.Printf("[INFO] Transferring Dns Secondary %d from master", secondaryId)
+
+		_, err = service.Id(secondaryId).TransferNow()
+		if err != nil {
+			return fmt.Errorf("Error transferring Dns Secondary %d: %s", secondaryId, err)
+		}
+	}
+
+	return resourceSoftLayerDnsSecondaryRead(d, meta)
+}
+
+func resourceSoftLayerDnsSecondaryDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetDnsSecondaryService(sess)
+
+	secondaryId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error deleting Dns Secondary: %s", err)
+	}
+
+	log.Printf("[INFO] Deleting Dns Secondary: %d", secondaryId)
+	result, err := service.Id(secondaryId).DeleteObject()
+	if err != nil {
+		return fmt.Errorf("Error deleting Dns Secondary: %s", err)
+	}
+
+	if !result {
+		return errors.New("Error deleting Dns Secondary")
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerDnsSecondaryExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+	service := services.GetDnsSecondaryService(sess)
+
+	secondaryId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	result, err := service.Id(secondaryId).Mask("id").GetObject()
+	return err == nil && result.Id != nil && *result.Id == secondaryId, nil
+}
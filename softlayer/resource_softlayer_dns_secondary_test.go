@@ -0,0 +1,73 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerDnsSecondary_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerDnsSecondaryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSoftLayerDnsSecondaryConfig, "10.0.0.1", 10, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_dns_secondary.dns-secondary-test", "zone_name", "dns-secondary-test.com"),
+					resource.TestCheckResourceAttr(
+						"softlayer_dns_secondary.dns-secondary-test", "master_ip_address", "10.0.0.1"),
+					resource.TestCheckResourceAttr(
+						"softlayer_dns_secondary.dns-secondary-test", "transfer_frequency", "10"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_dns_secondary.dns-secondary-test", "status"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSoftLayerDnsSecondaryConfig, "10.0.0.2", 15, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_dns_secondary.dns-secondary-test", "master_ip_address", "10.0.0.2"),
+					resource.TestCheckResourceAttr(
+						"softlayer_dns_secondary.dns-secondary-test", "transfer_frequency", "15"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerDnsSecondaryDestroy(s *terraform.State) error {
+	service := services.GetDnsSecondaryService(testAccProvider.Meta().(*session.Session))
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_dns_secondary" {
+			continue
+		}
+
+		secondaryId, _ := strconv.Atoi(rs.Primary.ID)
+
+		_, err := service.Id(secondaryId).GetObject()
+
+		if err == nil {
+			return fmt.Errorf("Dns Secondary with id %d still exists", secondaryId)
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckSoftLayerDnsSecondaryConfig = `
+resource "softlayer_dns_secondary" "dns-secondary-test" {
+    zone_name = "dns-secondary-test.com"
+    master_ip_address = "%s"
+    transfer_frequency = %d
+    transfer_trigger = "%s"
+}
+`
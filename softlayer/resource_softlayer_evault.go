@@ -0,0 +1,246 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/helpers/location"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	EvaultCategoryCode = "evault"
+
+	EvaultMask = "id,username,password,capacityGb,serviceResourceName,serviceResourceBackendIpAddress," +
+		"virtualGuest[id],hardware[id],serviceResource[datacenter[name]]"
+)
+
+func resourceSoftLayerEvault() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerEvaultCreate,
+		Read:     resourceSoftLayerEvaultRead,
+		Delete:   resourceSoftLayerEvaultDelete,
+		Exists:   resourceSoftLayerEvaultExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"datacenter": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"capacity": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"virtual_guest_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"hardware_id"},
+			},
+
+			"hardware_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"virtual_guest_id"},
+			},
+
+			"username": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"password": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"service_resource_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"service_resource_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerEvaultCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	capacity := d.Get("capacity").(int)
+	datacenter := d.Get("datacenter").(string)
+	virtualGuestId, hasVirtualGuest := d.GetOk("virtual_guest_id")
+	hardwareId, hasHardware := d.GetOk("hardware_id")
+
+	if !hasVirtualGuest && !hasHardware {
+		return fmt.Errorf("Error creating evault: one of virtual_guest_id or hardware_id must be set")
+	}
+
+	dc, err := location.GetDatacenterByName(sess, datacenter, "id,priceGroups[id]")
+	if err != nil {
+		return fmt.Errorf("Error creating evault: %s", err)
+	}
+
+	if dc.Id == nil {
+		return fmt.Errorf("Error creating evault: no datacenter found with name %s", datacenter)
+	}
+
+	pkgs, err := services.GetProductPackageService(sess).
+		Mask("id").
+		Filter(filter.Build(
+			filter.Path("categories.categoryCode").Eq(EvaultCategoryCode),
+			filter.Path("statusCode").Eq("ACTIVE"),
+		)).
+		GetAllObjects()
+	if err != nil {
+		return fmt.Errorf("Error creating evault: %s", err)
+	}
+
+	if len(pkgs) == 0 {
+		return fmt.Errorf("Error creating evault: no product packages found for %s", EvaultCategoryCode)
+	}
+
+	productItems, err := services.GetProductPackageService(sess).
+		Id(*pkgs[0].Id).
+		Mask(StoragePackageItemMask).
+		GetItems()
+	if err != nil {
+		return fmt.Errorf("Error creating evault: %s", err)
+	}
+
+	// The capacity tier is the capacity of the item.
+	prices, err := selectStorageItemPrices(productItems, []storagePriceSelector{
+		{EvaultCategoryCode, func(item datatypes.Product_Item, price datatypes.Product_Item_Price) bool {
+			return item.Capacity != nil && int(*item.Capacity) == capacity
+		}},
+	}, dc)
+	if err != nil {
+		return fmt.Errorf("Error creating evault: no %d GB capacity tier found: %s", capacity, err)
+	}
+
+	orderContainer := datatypes.Container_Product_Order_Network_Storage_Backup_Evault_Vault{
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: pkgs[0].Id,
+			Location:  sl.String(strconv.Itoa(*dc.Id)),
+			Prices:    prices,
+			Quantity:  sl.Int(1),
+		},
+	}
+
+	// The evault is linked to the server it backs up.
+	if hasVirtualGuest {
+		orderContainer.VirtualGuests = []datatypes.Virtual_Guest{{Id: sl.Int(virtualGuestId.(int))}}
+	} else {
+		orderContainer.Hardware = []datatypes.Hardware{{Id: sl.Int(hardwareId.(int))}}
+	}
+
+	log.Println("[INFO] Creating evault")
+
+	receipt, err := services.GetProductOrderService(sess).
+		PlaceOrder(&orderContainer, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of evault: %s", err)
+	}
+
+	evault, err := findStorageByOrderId(sess, *receipt.OrderId)
+	if err != nil {
+		return fmt.Errorf("Error finding created evault: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", *evault.Id))
+	log.Printf("[INFO] Evault ID: %s", d.Id())
+
+	return resourceSoftLayerEvaultRead(d, meta)
+}
+
+func resourceSoftLayerEvaultRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	evaultId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	evault, err := services.GetNetworkStorageBackupEvaultService(sess).
+		Id(evaultId).
+		Mask(EvaultMask).
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving evault: %s", err)
+	}
+
+	d.Set("capacity", sl.Get(evault.CapacityGb, 0))
+	d.Set("username", sl.Get(evault.Username, ""))
+	d.Set("password", sl.Get(evault.Password, ""))
+	d.Set("service_resource_name", sl.Get(evault.ServiceResourceName, ""))
+	d.Set("service_resource_address", sl.Get(evault.ServiceResourceBackendIpAddress, ""))
+
+	if evault.ServiceResource != nil && evault.ServiceResource.Datacenter != nil {
+		d.Set("datacenter", sl.Get(evault.ServiceResource.Datacenter.Name, ""))
+	}
+
+	if evault.VirtualGuest != nil {
+		d.Set("virtual_guest_id", sl.Get(evault.VirtualGuest.Id, 0))
+	}
+
+	if evault.Hardware != nil {
+		d.Set("hardware_id", sl.Get(evault.Hardware.Id, 0))
+	}
+
+	return nil
+}
+
+func resourceSoftLayerEvaultDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	evaultId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	billingItem, err := services.GetNetworkStorageBackupEvaultService(sess).Id(evaultId).GetBillingItem()
+	if err != nil {
+		return fmt.Errorf("Error deleting evault: %s", err)
+	}
+
+	if billingItem.Id == nil {
+		return fmt.Errorf("Error deleting evault: no billing item found for evault %d", evaultId)
+	}
+
+	log.Printf("[INFO] Cancelling evault: %d", evaultId)
+	_, err = services.GetBillingItemService(sess).Id(*billingItem.Id).CancelService()
+	if err != nil {
+		return fmt.Errorf("Error deleting evault: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerEvaultExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+
+	evaultId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	result, err := services.GetNetworkStorageBackupEvaultService(sess).Id(evaultId).Mask("id").GetObject()
+	return result.Id != nil && err == nil && *result.Id == evaultId, nil
+}
@@ -0,0 +1,81 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerEvault_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerEvaultDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerEvaultConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_evault.backup", "datacenter", "dal06"),
+					resource.TestCheckResourceAttr(
+						"softlayer_evault.backup", "capacity", "20"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_evault.backup", "virtual_guest_id"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_evault.backup", "username"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_evault.backup", "password"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_evault.backup", "service_resource_name"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerEvaultDestroy(s *terraform.State) error {
+	service := services.GetNetworkStorageBackupEvaultService(testAccProvider.Meta().(*session.Session))
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_evault" {
+			continue
+		}
+
+		evaultId, _ := strconv.Atoi(rs.Primary.ID)
+
+		billingItem, err := service.Id(evaultId).GetBillingItem()
+
+		if err == nil && billingItem.Id != nil {
+			return fmt.Errorf("Evault %d still exists", evaultId)
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckSoftLayerEvaultConfig_basic = `
+resource "softlayer_virtual_guest" "evaultvm1" {
+    name = "evaultvm1"
+    domain = "terraformuat.softlayer.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "dal06"
+    network_speed = 100
+    hourly_billing = true
+    private_network_only = false
+    cpu = 1
+    ram = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_evault" "backup" {
+    datacenter = "dal06"
+    capacity = 20
+    virtual_guest_id = "${softlayer_virtual_guest.evaultvm1.id}"
+}
+`
@@ -0,0 +1,356 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/helpers/location"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	FileStorageMask = "id,username,capacityGb,snapshotCapacityGb,iops,storageTierLevel,serviceResourceBackendIpAddress," +
+		"fileNetworkMountAddress,upgradableFlag,activeTransactionCount," +
+		"storageType[keyName],serviceResource[datacenter[name]]," +
+		"allowedVirtualGuests[id],allowedHardware[id],allowedSubnets[networkIdentifier,cidr],allowedIpAddresses[ipAddress]"
+)
+
+// The vendored softlayer-go does not include the fileNetworkMountAddress property of
+// SoftLayer_Network_Storage yet, so file storage is read into this type.
+type fileStorage struct {
+	datatypes.Network_Storage
+
+	FileNetworkMountAddress *string `json:"fileNetworkMountAddress,omitempty"`
+}
+
+func resourceSoftLayerFileStorage() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerFileStorageCreate,
+		Read:     resourceSoftLayerFileStorageRead,
+		Update:   resourceSoftLayerFileStorageUpdate,
+		Delete:   resourceSoftLayerFileStorageDelete,
+		Exists:   resourceSoftLayerFileStorageExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStorageType,
+			},
+
+			"datacenter": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"capacity": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"iops": {
+				Type:     schema.TypeFloat,
+				Required: true,
+			},
+
+			"snapshot_capacity": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"volume_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"mountpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"allowed_virtual_guest_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Set:      schema.HashInt,
+			},
+
+			"allowed_hardware_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Set:      schema.HashInt,
+			},
+
+			"allowed_subnets": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"allowed_ip_addresses": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerFileStorageCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	storageType := d.Get("type").(string)
+	capacity := d.Get("capacity").(int)
+	iops := d.Get("iops").(float64)
+	datacenter := d.Get("datacenter").(string)
+
+	dc, err := location.GetDatacenterByName(sess, datacenter, "id,priceGroups[id]")
+	if err != nil {
+		return fmt.Errorf("Error creating file storage: %s", err)
+	}
+
+	if dc.Id == nil {
+		return fmt.Errorf("Error creating file storage: no datacenter found with name %s", datacenter)
+	}
+
+	pkgId, prices, err := getStorageOrderPrices(sess, storageOrder{
+		storageType:      storageType,
+		storageProtocol:  "nfs",
+		capacity:         capacity,
+		iops:             iops,
+		snapshotCapacity: d.Get("snapshot_capacity").(int),
+	}, dc)
+	if err != nil {
+		return fmt.Errorf("Error creating file storage: %s", err)
+	}
+
+	orderContainer := datatypes.Container_Product_Order{
+		PackageId: sl.Int(pkgId),
+		Location:  sl.String(strconv.Itoa(*dc.Id)),
+		Prices:    prices,
+		Quantity:  sl.Int(1),
+	}
+
+	var productOrderContainer interface{}
+	if storageType == EnduranceType {
+		productOrderContainer = &datatypes.Container_Product_Order_Network_Storage_Enterprise{
+			Container_Product_Order: orderContainer,
+		}
+	} else {
+		productOrderContainer = &datatypes.Container_Product_Order_Network_PerformanceStorage_Nfs{
+			Container_Product_Order_Network_PerformanceStorage: datatypes.Container_Product_Order_Network_PerformanceStorage{
+				Container_Product_Order: orderContainer,
+			},
+		}
+	}
+
+	log.Println("[INFO] Creating file storage")
+
+	receipt, err := services.GetProductOrderService(sess).
+		PlaceOrder(productOrderContainer, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of file storage: %s", err)
+	}
+
+	storage, err := findStorageByOrderId(sess, *receipt.OrderId)
+	if err != nil {
+		return fmt.Errorf("Error finding created file storage: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", *storage.Id))
+	log.Printf("[INFO] File storage ID: %s", d.Id())
+
+	err = updateStorageAllowedHosts(d, meta, *storage.Id)
+	if err != nil {
+		return fmt.Errorf("Error updating allowed hosts of file storage: %s", err)
+	}
+
+	return resourceSoftLayerFileStorageRead(d, meta)
+}
+
+func resourceSoftLayerFileStorageRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	storageId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	storage, err := getFileStorage(sess, storageId, FileStorageMask)
+	if err != nil {
+		return fmt.Errorf("Error retrieving file storage: %s", err)
+	}
+
+	setStorageResourceData(d, storage.Network_Storage)
+
+	if storage.FileNetworkMountAddress != nil {
+		d.Set("mountpoint", *storage.FileNetworkMountAddress)
+	} else if storage.ServiceResourceBackendIpAddress != nil && storage.Username != nil {
+		d.Set("mountpoint", fmt.Sprintf("%s:/%s", *storage.ServiceResourceBackendIpAddress, *storage.Username))
+	}
+
+	return nil
+}
+
+func resourceSoftLayerFileStorageUpdate(d *schema.ResourceData, meta interface{}) error {
+	storageId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	// Snapshot space can't be decreased, which is checked before the volume is upgraded.
+	oldSnapshotCapacity, newSnapshotCapacity := d.GetChange("snapshot_capacity")
+	if newSnapshotCapacity.(int) < oldSnapshotCapacity.(int) {
+		return fmt.Errorf("Error updating snapshot space of file storage: snapshot_capacity can't be decreased from %d to %d",
+			oldSnapshotCapacity, newSnapshotCapacity)
+	}
+
+	if d.HasChange("capacity") || d.HasChange("iops") {
+		err = upgradeFileStorage(d, meta, storageId)
+		if err != nil {
+			return fmt.Errorf("Error updating file storage: %s", err)
+		}
+	}
+
+	if d.HasChange("snapshot_capacity") {
+		err = upgradeStorageSnapshotSpace(d, meta, storageId, "nfs")
+		if err != nil {
+			return fmt.Errorf("Error updating snapshot space of file storage: %s", err)
+		}
+	}
+
+	err = updateStorageAllowedHosts(d, meta, storageId)
+	if err != nil {
+		return fmt.Errorf("Error updating allowed hosts of file storage: %s", err)
+	}
+
+	return resourceSoftLayerFileStorageRead(d, meta)
+}
+
+func resourceSoftLayerFileStorageDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	storageId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	err = cancelStorage(sess, storageId)
+	if err != nil {
+		return fmt.Errorf("Error deleting file storage: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerFileStorageExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+
+	storageId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	result, err := services.GetNetworkStorageService(sess).Id(storageId).Mask("id").GetObject()
+	return result.Id != nil && err == nil && *result.Id == storageId, nil
+}
+
+func getFileStorage(sess *session.Session, storageId int, mask string) (fileStorage, error) {
+	storage := fileStorage{}
+
+	service := services.GetNetworkStorageService(sess).Id(storageId).Mask(mask)
+	err := sess.DoRequest("SoftLayer_Network_Storage", "getObject", nil, &service.Options, &storage)
+
+	return storage, err
+}
+
+// upgradeFileStorage orders the new capacity and IOPS of a volume as a modification of the volume,
+// and waits until the volume has been resized.
+func upgradeFileStorage(d *schema.ResourceData, meta interface{}, storageId int) error {
+	sess := meta.(*session.Session)
+
+	storage, err := getFileStorage(sess, storageId, "id,upgradableFlag")
+	if err != nil {
+		return err
+	}
+
+	if !sl.Get(storage.UpgradableFlag, false).(bool) {
+		return fmt.Errorf("Storage %d can't be upgraded", storageId)
+	}
+
+	datacenter := d.Get("datacenter").(string)
+	dc, err := location.GetDatacenterByName(sess, datacenter, "id,priceGroups[id]")
+	if err != nil {
+		return err
+	}
+
+	if dc.Id == nil {
+		return fmt.Errorf("No datacenter found with name %s", datacenter)
+	}
+
+	capacity := d.Get("capacity").(int)
+	pkgId, prices, err := getStorageOrderPrices(sess, storageOrder{
+		storageType:     d.Get("type").(string),
+		storageProtocol: "nfs",
+		capacity:        capacity,
+		iops:            d.Get("iops").(float64),
+		upgrade:         true,
+	}, dc)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Upgrading file storage: %d", storageId)
+
+	_, err = services.GetProductOrderService(sess).
+		PlaceOrder(&datatypes.Container_Product_Order_Network_Storage_Modification{
+			Container_Product_Order: datatypes.Container_Product_Order{
+				PackageId: sl.Int(pkgId),
+				Prices:    prices,
+				Quantity:  sl.Int(1),
+			},
+			VolumeId: sl.Int(storageId),
+		}, sl.Bool(false))
+	if err != nil {
+		return err
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			storage, err := getFileStorage(sess, storageId, "id,capacityGb,activeTransactionCount")
+			if err != nil {
+				return nil, "", err
+			}
+
+			if sl.Get(storage.CapacityGb, 0).(int) != capacity ||
+				sl.Get(storage.ActiveTransactionCount, uint(0)).(uint) > 0 {
+				return storage, "pending", nil
+			}
+
+			return storage, "complete", nil
+		},
+		Timeout:    45 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	return err
+}
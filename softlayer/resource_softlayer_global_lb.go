@@ -0,0 +1,296 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	GlobalLbCategoryCode = "global_load_balancer"
+
+	globalLbMask = "id,hostname,fallbackIp,loadBalanceTypeId,notes,allowedNumberOfHosts,connectionsPerSecond"
+
+	globalLbPackageItemMask = "id,keyName,prices[id,locationGroupId,categories[categoryCode]]"
+)
+
+// globalLbTypes maps the load balance methods of a global load balancer to the IDs of
+// SoftLayer_Network_LoadBalancer_Global_Type.
+var globalLbTypes = map[string]int{
+	"round robin":          1,
+	"failover":             2,
+	"weighted round robin": 3,
+}
+
+func resourceSoftLayerGlobalLb() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerGlobalLbCreate,
+		Read:     resourceSoftLayerGlobalLbRead,
+		Update:   resourceSoftLayerGlobalLbUpdate,
+		Delete:   resourceSoftLayerGlobalLbDelete,
+		Exists:   resourceSoftLayerGlobalLbExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"hostname": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"domain": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"fallback_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"load_balance_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "round robin",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					if _, ok := globalLbTypes[v.(string)]; !ok {
+						errors = append(errors, fmt.Errorf(
+							"Invalid load_balance_type: must be 'round robin', 'failover' or 'weighted round robin'"))
+					}
+					return
+				},
+			},
+
+			"notes": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"allowed_number_of_hosts": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"connections_per_second": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// selectGlobalLbItemPrice selects the price of a global load balancer. Global load balancers are not bound
+// to a datacenter, so the standard price, without a location group, is used.
+func selectGlobalLbItemPrice(productItems []datatypes.Product_Item) (datatypes.Product_Item_Price, error) {
+	for _, item := range productItems {
+		for _, price := range item.Prices {
+			if price.Id != nil && price.LocationGroupId == nil && priceHasCategory(price, GlobalLbCategoryCode) {
+				return datatypes.Product_Item_Price{Id: price.Id}, nil
+			}
+		}
+	}
+
+	return datatypes.Product_Item_Price{},
+		fmt.Errorf("No product items matching %s could be found", GlobalLbCategoryCode)
+}
+
+func resourceSoftLayerGlobalLbCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	pkgs, err := services.GetProductPackageService(sess).
+		Mask("id").
+		Filter(filter.Build(
+			filter.Path("categories.categoryCode").Eq(GlobalLbCategoryCode),
+			filter.Path("statusCode").Eq("ACTIVE"),
+		)).
+		GetAllObjects()
+	if err != nil {
+		return fmt.Errorf("Error creating global load balancer: %s", err)
+	}
+
+	if len(pkgs) == 0 {
+		return fmt.Errorf("Error creating global load balancer: no product packages found for %s",
+			GlobalLbCategoryCode)
+	}
+
+	productItems, err := services.GetProductPackageService(sess).
+		Id(*pkgs[0].Id).
+		Mask(globalLbPackageItemMask).
+		GetItems()
+	if err != nil {
+		return fmt.Errorf("Error creating global load balancer: %s", err)
+	}
+
+	price, err := selectGlobalLbItemPrice(productItems)
+	if err != nil {
+		return fmt.Errorf("Error creating global load balancer: %s", err)
+	}
+
+	hostname := d.Get("hostname").(string)
+	domain := d.Get("domain").(string)
+
+	log.Printf("[INFO] Creating global load balancer %s.%s", hostname, domain)
+
+	receipt, err := services.GetProductOrderService(sess).
+		PlaceOrder(&datatypes.Container_Product_Order_Network_LoadBalancer_Global{
+			Container_Product_Order: datatypes.Container_Product_Order{
+				PackageId: pkgs[0].Id,
+				Prices:    []datatypes.Product_Item_Price{price},
+				Quantity:  sl.Int(1),
+			},
+			Hostname: sl.String(hostname),
+			Domain:   sl.String(domain),
+		}, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of global load balancer: %s", err)
+	}
+
+	billingOrderItem, err := WaitForOrderCompletion(&receipt, meta)
+	if err != nil {
+		return fmt.Errorf("Error waiting for global load balancer order (%d) to complete: %s",
+			*receipt.OrderId, err)
+	}
+
+	accounts, err := services.GetAccountService(sess).
+		Mask("id").
+		Filter(filter.Path("globalLoadBalancerAccounts.billingItem.id").
+			Eq(*billingOrderItem.BillingItem.Id).Build()).
+		GetGlobalLoadBalancerAccounts()
+	if err != nil {
+		return fmt.Errorf("Error finding created global load balancer: %s", err)
+	}
+
+	if len(accounts) != 1 {
+		return fmt.Errorf("Expected one global load balancer, found %d", len(accounts))
+	}
+
+	d.SetId(fmt.Sprintf("%d", *accounts[0].Id))
+	log.Printf("[INFO] Global load balancer ID: %s", d.Id())
+
+	return resourceSoftLayerGlobalLbUpdate(d, meta)
+}
+
+func resourceSoftLayerGlobalLbRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	accountId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	account, err := services.GetNetworkLoadBalancerGlobalAccountService(sess).
+		Id(accountId).
+		Mask(globalLbMask).
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving global load balancer: %s", err)
+	}
+
+	// The hostname of the account is the fully qualified name which is load balanced.
+	fqdn := sl.Get(account.Hostname, "").(string)
+	if parts := strings.SplitN(fqdn, ".", 2); len(parts) == 2 {
+		d.Set("hostname", parts[0])
+		d.Set("domain", parts[1])
+	}
+
+	d.Set("fallback_ip", sl.Get(account.FallbackIp, ""))
+	d.Set("notes", sl.Get(account.Notes, ""))
+	d.Set("allowed_number_of_hosts", sl.Get(account.AllowedNumberOfHosts, 0))
+	d.Set("connections_per_second", sl.Get(account.ConnectionsPerSecond, 0))
+
+	for name, typeId := range globalLbTypes {
+		if typeId == sl.Get(account.LoadBalanceTypeId, 0) {
+			d.Set("load_balance_type", name)
+		}
+	}
+
+	return nil
+}
+
+func resourceSoftLayerGlobalLbUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	accountId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	if d.HasChange("fallback_ip") || d.HasChange("load_balance_type") || d.HasChange("notes") {
+		account := datatypes.Network_LoadBalancer_Global_Account{
+			FallbackIp:        sl.String(d.Get("fallback_ip").(string)),
+			LoadBalanceTypeId: sl.Int(globalLbTypes[d.Get("load_balance_type").(string)]),
+			Notes:             sl.String(d.Get("notes").(string)),
+		}
+
+		log.Printf("[INFO] Updating global load balancer %d", accountId)
+
+		success, err := services.GetNetworkLoadBalancerGlobalAccountService(sess).
+			Id(accountId).
+			EditObject(&account)
+		if err != nil {
+			return fmt.Errorf("Error updating global load balancer: %s", err)
+		}
+
+		if !success {
+			return fmt.Errorf("SoftLayer reported an unsuccessful update of global load balancer %d", accountId)
+		}
+	}
+
+	return resourceSoftLayerGlobalLbRead(d, meta)
+}
+
+func resourceSoftLayerGlobalLbDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	accountId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	billingItem, err := services.GetNetworkLoadBalancerGlobalAccountService(sess).Id(accountId).GetBillingItem()
+	if err != nil {
+		return fmt.Errorf("Error deleting global load balancer: %s", err)
+	}
+
+	if billingItem.Id == nil {
+		return fmt.Errorf("Error deleting global load balancer: no billing item found for global load balancer %d",
+			accountId)
+	}
+
+	log.Printf("[INFO] Cancelling global load balancer: %d", accountId)
+	_, err = services.GetBillingItemService(sess).Id(*billingItem.Id).CancelService()
+	if err != nil {
+		return fmt.Errorf("Error deleting global load balancer: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerGlobalLbExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+
+	accountId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	result, err := services.GetNetworkLoadBalancerGlobalAccountService(sess).
+		Id(accountId).
+		Mask("id,billingItem[id,cancellationDate]").
+		GetObject()
+
+	// A cancelled global load balancer stays visible until it is reclaimed, but its billing item is
+	// cancelled. The billing item is missing for users without billing permissions, so it is kept then.
+	return result.Id != nil && err == nil && *result.Id == accountId &&
+		(result.BillingItem == nil || result.BillingItem.CancellationDate == nil), nil
+}
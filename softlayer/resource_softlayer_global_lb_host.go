@@ -0,0 +1,257 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const globalLbHostMask = "id,destinationIp,destinationPort,weight,healthCheck,enabled,loadBalanceOrder," +
+	"location,status,loadBalancerAccount[id]"
+
+func resourceSoftLayerGlobalLbHost() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerGlobalLbHostCreate,
+		Read:     resourceSoftLayerGlobalLbHostRead,
+		Update:   resourceSoftLayerGlobalLbHostUpdate,
+		Delete:   resourceSoftLayerGlobalLbHostDelete,
+		Exists:   resourceSoftLayerGlobalLbHostExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"global_lb_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"destination_ip": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"destination_port": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			// The total weight of the hosts of a global load balancer must not exceed 100.
+			"weight": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"health_check": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "none",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					switch v.(string) {
+					case "none", "http", "tcp":
+					default:
+						errors = append(errors, fmt.Errorf("Invalid health_check: must be 'none', 'http' or 'tcp'"))
+					}
+					return
+				},
+			},
+
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			// The order of the host is only used by the failover load balance method.
+			"load_balance_order": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"location": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// expandGlobalLbHost returns the host template for editObject of the global load balancer.
+func expandGlobalLbHost(d *schema.ResourceData) datatypes.Network_LoadBalancer_Global_Host {
+	host := datatypes.Network_LoadBalancer_Global_Host{
+		DestinationIp:   sl.String(d.Get("destination_ip").(string)),
+		DestinationPort: sl.Int(d.Get("destination_port").(int)),
+		HealthCheck:     sl.String(d.Get("health_check").(string)),
+		Enabled:         sl.Int(0),
+	}
+
+	if d.Get("enabled").(bool) {
+		host.Enabled = sl.Int(1)
+	}
+
+	if weight, ok := d.GetOk("weight"); ok {
+		host.Weight = sl.Int(weight.(int))
+	}
+
+	if order, ok := d.GetOk("load_balance_order"); ok {
+		host.LoadBalanceOrder = sl.Int(order.(int))
+	}
+
+	return host
+}
+
+// editGlobalLbHost creates or updates a host through editObject of its global load balancer. Hosts without
+// an ID are created.
+func editGlobalLbHost(sess *session.Session, accountId int, host datatypes.Network_LoadBalancer_Global_Host) error {
+	success, err := services.GetNetworkLoadBalancerGlobalAccountService(sess).
+		Id(accountId).
+		EditObject(&datatypes.Network_LoadBalancer_Global_Account{
+			Hosts: []datatypes.Network_LoadBalancer_Global_Host{host},
+		})
+	if err != nil {
+		return err
+	}
+
+	if !success {
+		return fmt.Errorf("SoftLayer reported an unsuccessful update of global load balancer %d", accountId)
+	}
+
+	return nil
+}
+
+func resourceSoftLayerGlobalLbHostCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	accountId := d.Get("global_lb_id").(int)
+	host := expandGlobalLbHost(d)
+
+	log.Printf("[INFO] Creating host %s:%d of global load balancer %d",
+		*host.DestinationIp, *host.DestinationPort, accountId)
+
+	err := editGlobalLbHost(sess, accountId, host)
+	if err != nil {
+		return fmt.Errorf("Error creating global load balancer host: %s", err)
+	}
+
+	// editObject doesn't return the created host. The destination of a host is unique within its global
+	// load balancer, so the host is found by its destination.
+	hosts, err := services.GetNetworkLoadBalancerGlobalAccountService(sess).
+		Id(accountId).
+		Mask("id,destinationIp,destinationPort").
+		GetHosts()
+	if err != nil {
+		return fmt.Errorf("Error retrieving global load balancer hosts: %s", err)
+	}
+
+	for _, h := range hosts {
+		if sl.Get(h.DestinationIp, "") == *host.DestinationIp && sl.Get(h.DestinationPort, 0) == *host.DestinationPort {
+			d.SetId(fmt.Sprintf("%d", *h.Id))
+			log.Printf("[INFO] Global load balancer host ID: %s", d.Id())
+
+			return resourceSoftLayerGlobalLbHostRead(d, meta)
+		}
+	}
+
+	return fmt.Errorf("Error finding created host %s:%d of global load balancer %d",
+		*host.DestinationIp, *host.DestinationPort, accountId)
+}
+
+func resourceSoftLayerGlobalLbHostRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	hostId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	host, err := services.GetNetworkLoadBalancerGlobalHostService(sess).
+		Id(hostId).
+		Mask(globalLbHostMask).
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving global load balancer host: %s", err)
+	}
+
+	if host.LoadBalancerAccount != nil {
+		d.Set("global_lb_id", sl.Get(host.LoadBalancerAccount.Id, 0))
+	}
+
+	d.Set("destination_ip", sl.Get(host.DestinationIp, ""))
+	d.Set("destination_port", sl.Get(host.DestinationPort, 0))
+	d.Set("weight", sl.Get(host.Weight, 0))
+	d.Set("health_check", sl.Get(host.HealthCheck, "none"))
+	d.Set("enabled", sl.Get(host.Enabled, 0) == 1)
+	d.Set("load_balance_order", sl.Get(host.LoadBalanceOrder, 0))
+	d.Set("location", sl.Get(host.Location, ""))
+	d.Set("status", sl.Get(host.Status, ""))
+
+	return nil
+}
+
+func resourceSoftLayerGlobalLbHostUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	hostId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	host := expandGlobalLbHost(d)
+	host.Id = sl.Int(hostId)
+
+	log.Printf("[INFO] Updating global load balancer host %d", hostId)
+
+	err = editGlobalLbHost(sess, d.Get("global_lb_id").(int), host)
+	if err != nil {
+		return fmt.Errorf("Error updating global load balancer host: %s", err)
+	}
+
+	return resourceSoftLayerGlobalLbHostRead(d, meta)
+}
+
+func resourceSoftLayerGlobalLbHostDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	hostId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	log.Printf("[INFO] Deleting global load balancer host: %d", hostId)
+
+	success, err := services.GetNetworkLoadBalancerGlobalHostService(sess).Id(hostId).DeleteObject()
+	if err != nil {
+		return fmt.Errorf("Error deleting global load balancer host: %s", err)
+	}
+
+	if !success {
+		return fmt.Errorf("SoftLayer reported an unsuccessful deletion of global load balancer host %d", hostId)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerGlobalLbHostExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+
+	hostId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	result, err := services.GetNetworkLoadBalancerGlobalHostService(sess).Id(hostId).Mask("id").GetObject()
+	return result.Id != nil && err == nil && *result.Id == hostId, nil
+}
@@ -0,0 +1,99 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerGlobalLbHost_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerGlobalLbHostDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckSoftLayerGlobalLbHostConfig, 50, "tcp", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"softlayer_global_lb_host.testacc_host", "global_lb_id"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb_host.testacc_host", "destination_port", "80"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb_host.testacc_host", "weight", "50"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb_host.testacc_host", "health_check", "tcp"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb_host.testacc_host", "enabled", "true"),
+				),
+			},
+
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckSoftLayerGlobalLbHostConfig, 100, "http", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb_host.testacc_host", "weight", "100"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb_host.testacc_host", "health_check", "http"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb_host.testacc_host", "enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerGlobalLbHostDestroy(s *terraform.State) error {
+	service := services.GetNetworkLoadBalancerGlobalHostService(testAccProvider.Meta().(*session.Session))
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_global_lb_host" {
+			continue
+		}
+
+		hostId, _ := strconv.Atoi(rs.Primary.ID)
+
+		_, err := service.Id(hostId).Mask("id").GetObject()
+
+		if err == nil {
+			return fmt.Errorf("Global load balancer host %d still exists", hostId)
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckSoftLayerGlobalLbHostConfig = `
+resource "softlayer_virtual_guest" "testacc_vm" {
+    name = "testacc-glb-host"
+    domain = "terraformuat.com"
+    image = "DEBIAN_7_64"
+    datacenter = "wdc01"
+    public_network_speed = 10
+    hourly_billing = true
+    private_network_only = false
+    cpu = 1
+    ram = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_global_lb" "testacc_glb" {
+    hostname = "testacc-glb-host"
+    domain = "terraformuat.com"
+}
+
+resource "softlayer_global_lb_host" "testacc_host" {
+    global_lb_id = "${softlayer_global_lb.testacc_glb.id}"
+    destination_ip = "${softlayer_virtual_guest.testacc_vm.ipv4_address}"
+    destination_port = 80
+    weight = %d
+    health_check = "%s"
+    enabled = %t
+}
+`
@@ -0,0 +1,109 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func TestAccSoftLayerGlobalLb_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerGlobalLbDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerGlobalLbConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb.testacc_glb", "hostname", "testacc-glb"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb.testacc_glb", "domain", "terraformuat.com"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb.testacc_glb", "load_balance_type", "round robin"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_global_lb.testacc_glb", "allowed_number_of_hosts"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerGlobalLbConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb.testacc_glb", "load_balance_type", "failover"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb.testacc_glb", "fallback_ip", "10.0.0.1"),
+					resource.TestCheckResourceAttr(
+						"softlayer_global_lb.testacc_glb", "notes", "terraform test"),
+				),
+			},
+		},
+	})
+}
+
+func TestSelectGlobalLbItemPrice(t *testing.T) {
+	category := []datatypes.Product_Item_Category{{CategoryCode: sl.String(GlobalLbCategoryCode)}}
+
+	productItems := []datatypes.Product_Item{
+		{
+			Prices: []datatypes.Product_Item_Price{
+				{Id: sl.Int(1), Categories: []datatypes.Product_Item_Category{{CategoryCode: sl.String("other")}}},
+				{Id: sl.Int(2), LocationGroupId: sl.Int(503), Categories: category},
+				{Id: sl.Int(3), Categories: category},
+			},
+		},
+	}
+
+	price, err := selectGlobalLbItemPrice(productItems)
+	if err != nil || sl.Get(price.Id, 0) != 3 {
+		t.Errorf("Expected the standard price 3, got %v (%v)", sl.Get(price.Id, 0), err)
+	}
+
+	if _, err = selectGlobalLbItemPrice(productItems[0:0]); err == nil {
+		t.Error("Expected an error without global load balancer prices")
+	}
+}
+
+func testAccCheckSoftLayerGlobalLbDestroy(s *terraform.State) error {
+	service := services.GetNetworkLoadBalancerGlobalAccountService(testAccProvider.Meta().(*session.Session))
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_global_lb" {
+			continue
+		}
+
+		accountId, _ := strconv.Atoi(rs.Primary.ID)
+
+		account, err := service.Id(accountId).Mask("id,billingItem[id,cancellationDate]").GetObject()
+
+		if err == nil && account.BillingItem != nil && account.BillingItem.CancellationDate == nil {
+			return fmt.Errorf("Global load balancer %d still exists", accountId)
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckSoftLayerGlobalLbConfig_basic = `
+resource "softlayer_global_lb" "testacc_glb" {
+    hostname = "testacc-glb"
+    domain = "terraformuat.com"
+}
+`
+
+const testAccCheckSoftLayerGlobalLbConfig_updated = `
+resource "softlayer_global_lb" "testacc_glb" {
+    hostname = "testacc-glb"
+    domain = "terraformuat.com"
+    load_balance_type = "failover"
+    fallback_ip = "10.0.0.1"
+    notes = "terraform test"
+}
+`
@@ -28,6 +28,24 @@ const (
 		"sslEnabledFlag,loadBalancerHardware[datacenter[name]],ipAddress[ipAddress,subnetId]"
 )
 
+// lbLocalConnectionTiers are the connection limits of local load balancers, as published in the local lb
+// ordering screen in the customer portal. SoftLayer capacities don't match the published capacities, so
+// each published limit is translated to the capacity of its product item.
+var lbLocalConnectionTiers = map[int]float64{
+	LB_SMALL_15000_CONNECTIONS:  65000.0,
+	LB_LARGE_150000_CONNECTIONS: 130000.0,
+}
+
+func validateLbLocalConnections(v interface{}, k string) (ws []string, errors []error) {
+	connections := v.(int)
+	if _, ok := lbLocalConnectionTiers[connections]; !ok {
+		errors = append(errors, fmt.Errorf(
+			"Invalid connections: connections should be %d or %d",
+			LB_SMALL_15000_CONNECTIONS, LB_LARGE_150000_CONNECTIONS))
+	}
+	return
+}
+
 func resourceSoftLayerLbLocal() *schema.Resource {
 	return &schema.Resource{
 		Create:   resourceSoftLayerLbLocalCreate,
@@ -39,15 +57,16 @@ func resourceSoftLayerLbLocal() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"connections": {
-				Type:     schema.TypeInt,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validateLbLocalConnections,
 			},
 			"datacenter": {
 				Type:     schema.TypeString,
 				Required: true,
 				ForceNew: true,
 			},
+			// HA load balancers are a separate product, which can't be reached by an upgrade.
 			"ha_enabled": {
 				Type:     schema.TypeBool,
 				Required: true,
@@ -57,6 +76,10 @@ func resourceSoftLayerLbLocal() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"ssl_offload_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 			"ip_address": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -75,20 +98,9 @@ func resourceSoftLayerLbLocalCreate(d *schema.ResourceData, meta interface{}) er
 	connections := d.Get("connections").(int)
 	haEnabled := d.Get("ha_enabled").(bool)
 
-	// SoftLayer capacities don't match the published capacities as seen in the local lb
-	// ordering screen in the customer portal. Terraform exposes the published capacities.
-	// Create a translation map for those cases where the published capacity does not
-	// equal the actual actual capacity on the product_item.
-	capacities := map[int]float64{
-		15000:  65000.0,
-		150000: 130000.0,
-	}
-
-	var capacity float64
-	if c, ok := capacities[connections]; !ok {
-		capacity = float64(connections)
-	} else {
-		capacity = c
+	capacity, ok := lbLocalConnectionTiers[connections]
+	if !ok {
+		return fmt.Errorf("No connection tier found for %d connections", connections)
 	}
 
 	var keyname string
@@ -131,8 +143,20 @@ func resourceSoftLayerLbLocalCreate(d *schema.ResourceData, meta interface{}) er
 		},
 	)
 
+	if len(prices) == 0 {
+		return fmt.Errorf("No prices found for %s with a capacity of %g", keyname, capacity)
+	}
+
 	// Lookup the datacenter ID
-	dc, err := location.GetDatacenterByName(sess, d.Get("datacenter").(string))
+	datacenter := d.Get("datacenter").(string)
+	dc, err := location.GetDatacenterByName(sess, datacenter)
+	if err != nil {
+		return fmt.Errorf("Error during creation of load balancer: %s", err)
+	}
+
+	if dc.Id == nil {
+		return fmt.Errorf("Error during creation of load balancer: no datacenter found with name %s", datacenter)
+	}
 
 	productOrderContainer := datatypes.Container_Product_Order_Network_LoadBalancer{
 		Container_Product_Order: datatypes.Container_Product_Order{
@@ -152,6 +176,9 @@ func resourceSoftLayerLbLocalCreate(d *schema.ResourceData, meta interface{}) er
 	}
 
 	loadBalancer, err := findLoadBalancerByOrderId(sess, *receipt.OrderId)
+	if err != nil {
+		return fmt.Errorf("Error during creation of load balancer: %s", err)
+	}
 
 	d.SetId(fmt.Sprintf("%d", *loadBalancer.Id))
 	d.Set("connections", getConnectionLimit(*loadBalancer.ConnectionLimit))
@@ -162,22 +189,118 @@ func resourceSoftLayerLbLocalCreate(d *schema.ResourceData, meta interface{}) er
 
 	log.Printf("[INFO] Load Balancer ID: %s", d.Id())
 
-	return resourceSoftLayerLbLocalUpdate(d, meta)
+	if certificateId, ok := d.GetOk("security_certificate_id"); ok {
+		err = setLbLocalSecurityCertificate(sess, *loadBalancer.Id, certificateId.(int))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceSoftLayerLbLocalRead(d, meta)
 }
 
 func resourceSoftLayerLbLocalUpdate(d *schema.ResourceData, meta interface{}) error {
 	sess := meta.(*session.Session)
 
-	vipID, _ := strconv.Atoi(d.Id())
+	vipID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
 
-	vip := datatypes.Network_Application_Delivery_Controller_LoadBalancer_VirtualIpAddress{
-		SecurityCertificateId: sl.Int(d.Get("security_certificate_id").(int)),
+	if d.HasChange("connections") {
+		err = upgradeLbLocalConnections(sess, vipID, d.Get("connections").(int))
+		if err != nil {
+			return err
+		}
 	}
 
-	success, err := services.GetNetworkApplicationDeliveryControllerLoadBalancerVirtualIpAddressService(sess).
-		Id(vipID).
-		EditObject(&vip)
+	if d.HasChange("security_certificate_id") {
+		err = setLbLocalSecurityCertificate(sess, vipID, d.Get("security_certificate_id").(int))
+		if err != nil {
+			return err
+		}
+	}
 
+	return resourceSoftLayerLbLocalRead(d, meta)
+}
+
+// upgradeLbLocalConnections upgrades the connection limit of a load balancer to the given tier. Each upgrade
+// order raises the connection limit to the next tier, and connection limits can't be lowered.
+func upgradeLbLocalConnections(sess *session.Session, vipID int, connections int) error {
+	service := services.GetNetworkApplicationDeliveryControllerLoadBalancerVirtualIpAddressService(sess)
+
+	for {
+		vip, err := service.Id(vipID).Mask("id,connectionLimit").GetObject()
+		if err != nil {
+			return fmt.Errorf("Error retrieving load balancer: %s", err)
+		}
+
+		currentConnections := getConnectionLimit(sl.Get(vip.ConnectionLimit, 0).(int))
+		if currentConnections == connections {
+			return nil
+		}
+
+		if currentConnections > connections {
+			return fmt.Errorf("The connections of load balancer %d can't be lowered from %d to %d",
+				vipID, currentConnections, connections)
+		}
+
+		log.Printf("[INFO] Upgrading connection limit of load balancer %d from %d", vipID, currentConnections)
+
+		success, err := service.Id(vipID).UpgradeConnectionLimit()
+		if err != nil {
+			return fmt.Errorf("Error upgrading connection limit of load balancer: %s", err)
+		}
+
+		if !success {
+			return fmt.Errorf("SoftLayer reported an unsuccessful connection limit upgrade")
+		}
+
+		stateConf := &resource.StateChangeConf{
+			Pending: []string{"pending"},
+			Target:  []string{"complete"},
+			Refresh: func() (interface{}, string, error) {
+				vip, err := service.Id(vipID).Mask("id,connectionLimit").GetObject()
+				if err != nil {
+					return nil, "", err
+				}
+
+				if getConnectionLimit(sl.Get(vip.ConnectionLimit, 0).(int)) == currentConnections {
+					return vip, "pending", nil
+				}
+
+				return vip, "complete", nil
+			},
+			Timeout:    30 * time.Minute,
+			Delay:      10 * time.Second,
+			MinTimeout: 10 * time.Second,
+		}
+
+		_, err = stateConf.WaitForState()
+		if err != nil {
+			return fmt.Errorf("Error waiting for connection limit upgrade of load balancer: %s", err)
+		}
+	}
+}
+
+// setLbLocalSecurityCertificate attaches a certificate to a load balancer and starts SSL offload, or stops
+// SSL offload and detaches the certificate if certificateId is 0.
+func setLbLocalSecurityCertificate(sess *session.Session, vipID int, certificateId int) error {
+	service := services.GetNetworkApplicationDeliveryControllerLoadBalancerVirtualIpAddressService(sess)
+
+	if certificateId == 0 {
+		log.Printf("[INFO] Stopping SSL offload of load balancer %d", vipID)
+		_, err := service.Id(vipID).StopSsl()
+		if err != nil {
+			return fmt.Errorf("Error stopping SSL offload of load balancer: %s", err)
+		}
+	}
+
+	vip := datatypes.Network_Application_Delivery_Controller_LoadBalancer_VirtualIpAddress{
+		SecurityCertificateId: sl.Int(certificateId),
+	}
+
+	success, err := service.Id(vipID).EditObject(&vip)
 	if err != nil {
 		return fmt.Errorf("Update load balancer failed: %s", err)
 	}
@@ -186,7 +309,15 @@ func resourceSoftLayerLbLocalUpdate(d *schema.ResourceData, meta interface{}) er
 		return fmt.Errorf("Update load balancer failed: %s", err)
 	}
 
-	return resourceSoftLayerLbLocalRead(d, meta)
+	if certificateId != 0 {
+		log.Printf("[INFO] Starting SSL offload of load balancer %d", vipID)
+		_, err = service.Id(vipID).StartSsl()
+		if err != nil {
+			return fmt.Errorf("Error starting SSL offload of load balancer: %s", err)
+		}
+	}
+
+	return nil
 }
 
 func resourceSoftLayerLbLocalRead(d *schema.ResourceData, meta interface{}) error {
@@ -211,6 +342,7 @@ func resourceSoftLayerLbLocalRead(d *schema.ResourceData, meta interface{}) erro
 
 	// Optional fields.  Guard against nil pointer dereferences
 	d.Set("security_certificate_id", sl.Get(vip.SecurityCertificateId, nil))
+	d.Set("ssl_offload_enabled", sl.Get(vip.SslEnabledFlag, false))
 
 	return nil
 }
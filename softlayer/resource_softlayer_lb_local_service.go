@@ -4,7 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/softlayer/softlayer-go/datatypes"
@@ -14,6 +17,12 @@ import (
 	"github.com/softlayer/softlayer-go/sl"
 )
 
+// lbLocalHealthCheckAttributes are the attributes which can be set for each health check type. Health
+// checks of other types have no attributes.
+var lbLocalHealthCheckAttributes = map[string][]string{
+	"HTTP-CUSTOM": {"HTTP_CUSTOM_TYPE", "LOCATION", "EXPECTED_RESPONSE"},
+}
+
 func resourceSoftLayerLbLocalService() *schema.Resource {
 	return &schema.Resource{
 		Create:   resourceSoftLayerLbLocalServiceCreate,
@@ -45,6 +54,10 @@ func resourceSoftLayerLbLocalService() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"health_check_attributes": {
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
 			"weight": {
 				Type:     schema.TypeInt,
 				Required: true,
@@ -81,6 +94,12 @@ func resourceSoftLayerLbLocalServiceCreate(d *schema.ResourceData, meta interfac
 		return err
 	}
 
+	healthAttributes, err := expandLbLocalHealthAttributes(sess,
+		d.Get("health_check_type").(string), d.Get("health_check_attributes").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
 	// The API only exposes edit capability at the root of the tree (virtualIpAddress),
 	// so need to send the full structure from the root down to the node to be added or
 	// modified
@@ -103,6 +122,7 @@ func resourceSoftLayerLbLocalServiceCreate(d *schema.ResourceData, meta interfac
 
 					HealthChecks: []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Check{{
 						HealthCheckTypeId: &healthCheckTypeId,
+						Attributes:        healthAttributes,
 					}},
 
 					GroupReferences: []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Service_Group_CrossReference{{
@@ -173,6 +193,12 @@ func resourceSoftLayerLbLocalServiceUpdate(d *schema.ResourceData, meta interfac
 		return err
 	}
 
+	healthAttributes, err := expandLbLocalHealthAttributes(sess,
+		d.Get("health_check_type").(string), d.Get("health_check_attributes").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
 	// The API only exposes edit capability at the root of the tree (virtualIpAddress),
 	// so need to send the full structure from the root down to the node to be added or
 	// modified
@@ -196,6 +222,7 @@ func resourceSoftLayerLbLocalServiceUpdate(d *schema.ResourceData, meta interfac
 
 					HealthChecks: []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Check{{
 						HealthCheckTypeId: &healthCheckTypeId,
+						Attributes:        healthAttributes,
 					}},
 
 					GroupReferences: []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Service_Group_CrossReference{{
@@ -230,7 +257,7 @@ func resourceSoftLayerLbLocalServiceRead(d *schema.ResourceData, meta interface{
 
 	svc, err := services.GetNetworkApplicationDeliveryControllerLoadBalancerServiceService(sess).
 		Id(svcID).
-		Mask("ipAddressId,port,healthChecks[type[keyname]],groupReferences[weight]").
+		Mask("ipAddressId,port,healthChecks[type[keyname],attributes[value,type[keyname]]],groupReferences[weight]").
 		GetObject()
 
 	if err != nil {
@@ -240,6 +267,7 @@ func resourceSoftLayerLbLocalServiceRead(d *schema.ResourceData, meta interface{
 	d.Set("ip_address_id", *svc.IpAddressId)
 	d.Set("port", *svc.Port)
 	d.Set("health_check_type", *svc.HealthChecks[0].Type.Keyname)
+	d.Set("health_check_attributes", flattenLbLocalHealthAttributes(svc.HealthChecks[0].Attributes))
 	d.Set("weight", *svc.GroupReferences[0].Weight)
 
 	return nil
@@ -296,3 +324,90 @@ func getHealthCheckTypeId(sess *session.Session, healthCheckTypeName string) (in
 
 	return *healthCheckTypes[0].Id, nil
 }
+
+// expandLbLocalHealthAttributes returns the attributes of a health check, e.g. the URL path of an HTTP-CUSTOM
+// check. Attribute names must be supported by the health check type, and values must match the value
+// expression of their attribute type.
+func expandLbLocalHealthAttributes(sess *session.Session, healthCheckType string, attributes map[string]interface{}) (
+	[]datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Attribute, error) {
+	healthAttributes := []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Attribute{}
+	if len(attributes) == 0 {
+		return healthAttributes, nil
+	}
+
+	supported := lbLocalHealthCheckAttributes[healthCheckType]
+	for name := range attributes {
+		if !isSupportedHealthAttribute(supported, name) {
+			if len(supported) == 0 {
+				return nil, fmt.Errorf("Health check type %s has no attributes, got %s", healthCheckType, name)
+			}
+			return nil, fmt.Errorf("Invalid attribute %s for health check type %s, should be one of %s",
+				name, healthCheckType, strings.Join(supported, ", "))
+		}
+	}
+
+	attributeTypes, err := services.GetNetworkApplicationDeliveryControllerLoadBalancerHealthAttributeTypeService(sess).
+		Mask("id,keyname,valueExpression").
+		GetAllObjects()
+
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving health attribute types: %s", err)
+	}
+
+	// Sort the attribute names, so the attributes are always sent in the same order
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := attributes[name].(string)
+
+		var attributeType *datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Attribute_Type
+		for i := range attributeTypes {
+			if attributeTypes[i].Keyname != nil && *attributeTypes[i].Keyname == name {
+				attributeType = &attributeTypes[i]
+				break
+			}
+		}
+
+		if attributeType == nil {
+			return nil, fmt.Errorf("Invalid health attribute type: %s", name)
+		}
+
+		if expression := sl.Get(attributeType.ValueExpression, "").(string); expression != "" {
+			r, err := regexp.Compile(expression)
+			if err == nil && !r.MatchString(value) {
+				return nil, fmt.Errorf("Invalid value for health attribute %s: %s should match %s", name, value, expression)
+			}
+		}
+
+		healthAttributes = append(healthAttributes, datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Attribute{
+			HealthAttributeTypeId: attributeType.Id,
+			Value:                 sl.String(value),
+		})
+	}
+
+	return healthAttributes, nil
+}
+
+func isSupportedHealthAttribute(supported []string, name string) bool {
+	for _, s := range supported {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+func flattenLbLocalHealthAttributes(
+	healthAttributes []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Attribute) map[string]interface{} {
+	attributes := map[string]interface{}{}
+	for _, attribute := range healthAttributes {
+		if attribute.Type != nil && attribute.Type.Keyname != nil {
+			attributes[*attribute.Type.Keyname] = sl.Get(attribute.Value, "")
+		}
+	}
+	return attributes
+}
@@ -55,6 +55,37 @@ func resourceSoftLayerLbLocalServiceGroup() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			// Inline services are managed together with the service group, so they can't race with each
+			// other. Don't combine them with softlayer_lb_local_service resources for the same group.
+			"service": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip_address_id": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"weight": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"health_check_type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -117,6 +148,10 @@ func resourceSoftLayerLbLocalServiceGroupCreate(d *schema.ResourceData, meta int
 
 	log.Printf("[INFO] Load Balancer Service Group ID: %s", d.Id())
 
+	if _, ok := d.GetOk("service"); ok {
+		return resourceSoftLayerLbLocalServiceGroupUpdate(d, meta)
+	}
+
 	return resourceSoftLayerLbLocalServiceGroupRead(d, meta)
 }
 
@@ -137,6 +172,22 @@ func resourceSoftLayerLbLocalServiceGroupUpdate(d *schema.ResourceData, meta int
 		return err
 	}
 
+	serviceGroup := datatypes.Network_Application_Delivery_Controller_LoadBalancer_Service_Group{
+		Id:              &sgID,
+		RoutingMethodId: &routingMethodId,
+		RoutingTypeId:   &routingTypeId,
+	}
+
+	// The services are sent with the service group, so the whole group is changed by a single editObject
+	// call. Removed services are not dropped by editObject, they are deleted afterwards.
+	var removedServiceIds []int
+	if d.HasChange("service") {
+		serviceGroup.Services, removedServiceIds, err = expandLbLocalServices(d, sess, vsID)
+		if err != nil {
+			return err
+		}
+	}
+
 	vip := datatypes.Network_Application_Delivery_Controller_LoadBalancer_VirtualIpAddress{
 
 		VirtualServers: []datatypes.Network_Application_Delivery_Controller_LoadBalancer_VirtualServer{{
@@ -144,11 +195,9 @@ func resourceSoftLayerLbLocalServiceGroupUpdate(d *schema.ResourceData, meta int
 			Allocation: sl.Int(d.Get("allocation").(int)),
 			Port:       sl.Int(d.Get("port").(int)),
 
-			ServiceGroups: []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Service_Group{{
-				Id:              &sgID,
-				RoutingMethodId: &routingMethodId,
-				RoutingTypeId:   &routingTypeId,
-			}},
+			ServiceGroups: []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Service_Group{
+				serviceGroup,
+			},
 		}},
 	}
 
@@ -166,9 +215,127 @@ func resourceSoftLayerLbLocalServiceGroupUpdate(d *schema.ResourceData, meta int
 		return errors.New("Error updating load balancer service group")
 	}
 
+	for _, svcID := range removedServiceIds {
+		log.Printf("[INFO] Deleting load balancer service %d", svcID)
+
+		err = services.GetNetworkApplicationDeliveryControllerLoadBalancerServiceService(sess).
+			Id(svcID).
+			DeleteObject()
+
+		if err != nil {
+			return fmt.Errorf("Error deleting service: %s", err)
+		}
+	}
+
 	return resourceSoftLayerLbLocalServiceGroupRead(d, meta)
 }
 
+// lbLocalServiceKey identifies a service of a service group by its IP address and port.
+func lbLocalServiceKey(ipAddressId int, port int) string {
+	return fmt.Sprintf("%d:%d", ipAddressId, port)
+}
+
+// getLbLocalServices returns the services of the service group of a virtual server.
+func getLbLocalServices(sess *session.Session, vsID int) (
+	[]datatypes.Network_Application_Delivery_Controller_LoadBalancer_Service, error) {
+	vs, err := services.GetNetworkApplicationDeliveryControllerLoadBalancerVirtualServerService(sess).
+		Id(vsID).
+		Mask("id,serviceGroups[services[id,ipAddressId,port,enabled,healthChecks[id,type[keyname]],groupReferences[weight]]]").
+		GetObject()
+
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving load balancer services: %s", err)
+	}
+
+	if len(vs.ServiceGroups) == 0 {
+		return nil, nil
+	}
+
+	return vs.ServiceGroups[0].Services, nil
+}
+
+// expandLbLocalServices returns the services of the service group as they are sent to editObject, and the
+// IDs of the existing services which are no longer in the service group. Existing services keep their ID,
+// so they are changed instead of added again.
+func expandLbLocalServices(d *schema.ResourceData, sess *session.Session, vsID int) (
+	[]datatypes.Network_Application_Delivery_Controller_LoadBalancer_Service, []int, error) {
+	existing, err := getLbLocalServices(sess, vsID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	existingByKey := map[string]datatypes.Network_Application_Delivery_Controller_LoadBalancer_Service{}
+	for _, svc := range existing {
+		existingByKey[lbLocalServiceKey(sl.Get(svc.IpAddressId, 0).(int), sl.Get(svc.Port, 0).(int))] = svc
+	}
+
+	healthCheckTypeIds := map[string]int{}
+	seen := map[string]bool{}
+	lbServices := []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Service{}
+	for _, s := range d.Get("service").(*schema.Set).List() {
+		service := s.(map[string]interface{})
+		ipAddressId := service["ip_address_id"].(int)
+		port := service["port"].(int)
+
+		key := lbLocalServiceKey(ipAddressId, port)
+		if seen[key] {
+			return nil, nil, fmt.Errorf("The service %s is defined more than once", key)
+		}
+		seen[key] = true
+
+		// Convert the health check type name to an ID
+		healthCheckType := service["health_check_type"].(string)
+		healthCheckTypeId, ok := healthCheckTypeIds[healthCheckType]
+		if !ok {
+			healthCheckTypeId, err = getHealthCheckTypeId(sess, healthCheckType)
+			if err != nil {
+				return nil, nil, err
+			}
+			healthCheckTypeIds[healthCheckType] = healthCheckTypeId
+		}
+
+		enabled := 0
+		if service["enabled"].(bool) {
+			enabled = 1
+		}
+
+		healthCheck := datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Check{
+			HealthCheckTypeId: sl.Int(healthCheckTypeId),
+		}
+
+		lbService := datatypes.Network_Application_Delivery_Controller_LoadBalancer_Service{
+			Enabled:     sl.Int(enabled),
+			Port:        sl.Int(port),
+			IpAddressId: sl.Int(ipAddressId),
+
+			GroupReferences: []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Service_Group_CrossReference{{
+				Weight: sl.Int(service["weight"].(int)),
+			}},
+		}
+
+		if svc, ok := existingByKey[key]; ok {
+			lbService.Id = svc.Id
+			if len(svc.HealthChecks) > 0 {
+				healthCheck.Id = svc.HealthChecks[0].Id
+			}
+			delete(existingByKey, key)
+		}
+
+		lbService.HealthChecks = []datatypes.Network_Application_Delivery_Controller_LoadBalancer_Health_Check{
+			healthCheck,
+		}
+
+		lbServices = append(lbServices, lbService)
+	}
+
+	removedServiceIds := []int{}
+	for _, svc := range existingByKey {
+		removedServiceIds = append(removedServiceIds, *svc.Id)
+	}
+
+	return lbServices, removedServiceIds, nil
+}
+
 func resourceSoftLayerLbLocalServiceGroupRead(d *schema.ResourceData, meta interface{}) error {
 	sess := meta.(*session.Session)
 
@@ -189,6 +356,36 @@ func resourceSoftLayerLbLocalServiceGroupRead(d *schema.ResourceData, meta inter
 	d.Set("routing_method", *vs.ServiceGroups[0].RoutingMethod.Keyname)
 	d.Set("routing_type", *vs.ServiceGroups[0].RoutingType.Keyname)
 
+	// Services are only read if they are managed inline, so service groups with softlayer_lb_local_service
+	// resources show no diff.
+	if d.Get("service").(*schema.Set).Len() > 0 {
+		lbServices, err := getLbLocalServices(sess, vsID)
+		if err != nil {
+			return err
+		}
+
+		serviceList := make([]map[string]interface{}, 0, len(lbServices))
+		for _, svc := range lbServices {
+			service := map[string]interface{}{
+				"ip_address_id": sl.Get(svc.IpAddressId, 0),
+				"port":          sl.Get(svc.Port, 0),
+				"enabled":       sl.Get(svc.Enabled, 0) == 1,
+			}
+
+			if len(svc.HealthChecks) > 0 && svc.HealthChecks[0].Type != nil {
+				service["health_check_type"] = sl.Get(svc.HealthChecks[0].Type.Keyname, "")
+			}
+
+			if len(svc.GroupReferences) > 0 {
+				service["weight"] = sl.Get(svc.GroupReferences[0].Weight, 0)
+			}
+
+			serviceList = append(serviceList, service)
+		}
+
+		d.Set("service", serviceList)
+	}
+
 	return nil
 }
 
@@ -1,6 +1,7 @@
 package softlayer
 
 import (
+	"fmt"
 	"github.com/hashicorp/terraform/helper/resource"
 	"testing"
 )
@@ -27,6 +28,29 @@ func TestAccSoftLayerLbLocalServiceGroup_Basic(t *testing.T) {
 	})
 }
 
+func TestAccSoftLayerLbLocalServiceGroup_Services(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckSoftLayerLbLocalServiceGroupConfig_services, 1, "true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_local_service_group.test_service_group", "service.#", "2"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccCheckSoftLayerLbLocalServiceGroupConfig_services, 2, "false"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_local_service_group.test_service_group", "service.#", "2"),
+				),
+			},
+		},
+	})
+}
+
 const testAccCheckSoftLayerLbLocalServiceGroupConfig_basic = `
 resource "softlayer_lb_local" "testacc_foobar_lb" {
     connections = 15000
@@ -42,3 +66,49 @@ resource "softlayer_lb_local_service_group" "test_service_group" {
     allocation = 100
 }
 `
+
+const testAccCheckSoftLayerLbLocalServiceGroupConfig_services = `
+resource "softlayer_virtual_guest" "test_server" {
+    count = 2
+    name = "terraform-test-${count.index}"
+    domain = "bar.example.com"
+    image = "DEBIAN_7_64"
+    datacenter = "tok02"
+    public_network_speed = 10
+    hourly_billing = true
+    private_network_only = false
+    cpu = 1
+    ram = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_lb_local" "testacc_foobar_lb" {
+    connections = 15000
+    datacenter    = "tok02"
+    ha_enabled  = false
+}
+
+resource "softlayer_lb_local_service_group" "test_service_group" {
+    port = 82
+    routing_method = "CONSISTENT_HASH_IP"
+    routing_type = "HTTP"
+    load_balancer_id = "${softlayer_lb_local.testacc_foobar_lb.id}"
+    allocation = 100
+
+    service {
+        ip_address_id = "${softlayer_virtual_guest.test_server.0.ip_address_id}"
+        port = 80
+        weight = %[1]d
+        health_check_type = "HTTP"
+    }
+
+    service {
+        ip_address_id = "${softlayer_virtual_guest.test_server.1.ip_address_id}"
+        port = 80
+        weight = 1
+        health_check_type = "HTTP"
+        enabled = %[2]s
+    }
+}
+`
@@ -21,6 +21,16 @@ func TestAccSoftLayerLbLocal_Basic(t *testing.T) {
 						"softlayer_lb_local.testacc_foobar_lb", "ha_enabled", "false"),
 				),
 			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerLbLocalConfig_upgrade,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_local.testacc_foobar_lb", "connections", "150000"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_local.testacc_foobar_lb", "ha_enabled", "false"),
+				),
+			},
 		},
 	})
 }
@@ -31,3 +41,10 @@ resource "softlayer_lb_local" "testacc_foobar_lb" {
     datacenter    = "tok02"
     ha_enabled  = false
 }`
+
+const testAccCheckSoftLayerLbLocalConfig_upgrade = `
+resource "softlayer_lb_local" "testacc_foobar_lb" {
+    connections = 150000
+    datacenter    = "tok02"
+    ha_enabled  = false
+}`
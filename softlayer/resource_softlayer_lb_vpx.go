@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -27,11 +28,14 @@ const (
 
 func resourceSoftLayerLbVpx() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceSoftLayerLbVpxCreate,
-		Read:     resourceSoftLayerLbVpxRead,
-		Delete:   resourceSoftLayerLbVpxDelete,
-		Exists:   resourceSoftLayerLbVpxExists,
-		Importer: &schema.ResourceImporter{},
+		Create: resourceSoftLayerLbVpxCreate,
+		Read:   resourceSoftLayerLbVpxRead,
+		Update: resourceSoftLayerLbVpxUpdate,
+		Delete: resourceSoftLayerLbVpxDelete,
+		Exists: resourceSoftLayerLbVpxExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceSoftLayerLbVpxImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
@@ -53,25 +57,21 @@ func resourceSoftLayerLbVpx() *schema.Resource {
 			"speed": &schema.Schema{
 				Type:     schema.TypeInt,
 				Required: true,
-				ForceNew: true,
 			},
 
 			"version": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 
 			"plan": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 
 			"ip_count": &schema.Schema{
 				Type:     schema.TypeInt,
 				Required: true,
-				ForceNew: true,
 			},
 
 			"front_end_vlan": &schema.Schema{
@@ -133,6 +133,33 @@ func resourceSoftLayerLbVpx() *schema.Resource {
 				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+
+			"vip_pool_subnet": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"management_ip_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"ha": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+
+			"secondary_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"secondary_management_ip_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -165,11 +192,22 @@ func getVlanId(vlanNumber int, primaryRouterHostname string, meta interface{}) (
 }
 
 func getSubnetId(subnet string, meta interface{}) (int, error) {
+	result, err := getSubnet(subnet, "id", meta)
+	if err != nil {
+		return 0, err
+	}
+
+	return *result.Id, nil
+}
+
+// getSubnet finds the subnet of the account which matches the provided subnet in
+// "network identifier/cidr" notation.
+func getSubnet(subnet string, mask string, meta interface{}) (datatypes.Network_Subnet, error) {
 	service := services.GetAccountService(meta.(*session.Session))
 
 	subnetInfo := strings.Split(subnet, "/")
 	if len(subnetInfo) != 2 {
-		return 0, fmt.Errorf(
+		return datatypes.Network_Subnet{}, fmt.Errorf(
 			"Unable to parse the provided subnet: %s", subnet)
 	}
 
@@ -177,7 +215,7 @@ func getSubnetId(subnet string, meta interface{}) (int, error) {
 	cidr := subnetInfo[1]
 
 	subnets, err := service.
-		Mask("id").
+		Mask(mask).
 		Filter(
 			filter.Build(
 				filter.Path("subnets.cidr").Eq(cidr),
@@ -187,15 +225,15 @@ func getSubnetId(subnet string, meta interface{}) (int, error) {
 		GetSubnets()
 
 	if err != nil {
-		return 0, fmt.Errorf("Error looking up Subnet: %s", err)
+		return datatypes.Network_Subnet{}, fmt.Errorf("Error looking up Subnet: %s", err)
 	}
 
 	if len(subnets) < 1 {
-		return 0, fmt.Errorf(
+		return datatypes.Network_Subnet{}, fmt.Errorf(
 			"Unable to locate a subnet matching the provided subnet: %s", subnet)
 	}
 
-	return *subnets[0].Id, nil
+	return subnets[0], nil
 }
 
 func getVPXPriceItemKeyName(version string, speed int, plan string) string {
@@ -270,7 +308,9 @@ func findVPXPriceItems(version string, speed int, plan string, ipCount int, meta
 	}, nil
 }
 
-func findVPXByOrderId(orderId int, meta interface{}) (datatypes.Network_Application_Delivery_Controller, error) {
+// findVPXsByOrderId waits until the given number of VPXs of an order are provisioned, and returns them
+// sorted by ID.
+func findVPXsByOrderId(orderId int, count int, meta interface{}) ([]datatypes.Network_Application_Delivery_Controller, error) {
 	service := services.GetAccountService(meta.(*session.Session))
 
 	stateConf := &resource.StateChangeConf{
@@ -284,15 +324,15 @@ func findVPXByOrderId(orderId int, meta interface{}) (datatypes.Network_Applicat
 					),
 				).GetApplicationDeliveryControllers()
 			if err != nil {
-				return datatypes.Network_Application_Delivery_Controller{}, "", err
+				return nil, "", err
 			}
 
-			if len(vpxs) == 1 {
-				return vpxs[0], "complete", nil
-			} else if len(vpxs) == 0 {
+			if len(vpxs) == count {
+				return vpxs, "complete", nil
+			} else if len(vpxs) < count {
 				return nil, "pending", nil
 			} else {
-				return nil, "", fmt.Errorf("Expected one VPX: %s", err)
+				return nil, "", fmt.Errorf("Expected %d VPXs, found %d", count, len(vpxs))
 			}
 		},
 		Timeout:    10 * time.Minute,
@@ -303,19 +343,25 @@ func findVPXByOrderId(orderId int, meta interface{}) (datatypes.Network_Applicat
 	pendingResult, err := stateConf.WaitForState()
 
 	if err != nil {
-		return datatypes.Network_Application_Delivery_Controller{}, err
+		return nil, err
 	}
 
-	var result, ok = pendingResult.(datatypes.Network_Application_Delivery_Controller)
+	var result, ok = pendingResult.([]datatypes.Network_Application_Delivery_Controller)
 
 	if ok {
+		sort.Sort(vpxsById(result))
 		return result, nil
 	}
 
-	return datatypes.Network_Application_Delivery_Controller{},
-		fmt.Errorf("Cannot find Application Delivery Controller with order id '%d'", orderId)
+	return nil, fmt.Errorf("Cannot find Application Delivery Controller with order id '%d'", orderId)
 }
 
+type vpxsById []datatypes.Network_Application_Delivery_Controller
+
+func (v vpxsById) Len() int           { return len(v) }
+func (v vpxsById) Swap(i, j int)      { v[i], v[j] = v[j], v[i] }
+func (v vpxsById) Less(i, j int) bool { return *v[i].Id < *v[j].Id }
+
 func prepareHardwareOptions(d *schema.ResourceData, meta interface{}) ([]datatypes.Hardware, error) {
 	hardwareOpts := make([]datatypes.Hardware, 1)
 
@@ -377,12 +423,17 @@ func resourceSoftLayerLbVpxCreate(d *schema.ResourceData, meta interface{}) erro
 	sess := meta.(*session.Session)
 
 	productOrderService := services.GetProductOrderService(sess)
-	NADCService := services.GetNetworkApplicationDeliveryControllerService(sess)
 	var err error
 
+	// An HA pair is ordered as two VPXs with the same options.
+	quantity := 1
+	if d.Get("ha").(bool) {
+		quantity = 2
+	}
+
 	opts := datatypes.Container_Product_Order{
 		PackageId: sl.Int(PACKAGE_ID_APPLICATION_DELIVERY_CONTROLLER),
-		Quantity:  sl.Int(1),
+		Quantity:  sl.Int(quantity),
 	}
 
 	opts.Prices, err = findVPXPriceItems(
@@ -399,11 +450,15 @@ func resourceSoftLayerLbVpxCreate(d *schema.ResourceData, meta interface{}) erro
 	datacenter := d.Get("datacenter").(string)
 
 	if len(datacenter) > 0 {
-		datacenter, err := location.GetDatacenterByName(sess, datacenter, "id")
+		dc, err := location.GetDatacenterByName(sess, datacenter, "id")
 		if err != nil {
 			return fmt.Errorf("Error creating network application delivery controller: %s", err)
 		}
-		opts.Location = sl.String(strconv.Itoa(*datacenter.Id))
+		if dc.Id == nil {
+			return fmt.Errorf(
+				"Error creating network application delivery controller: no datacenter found with name %s", datacenter)
+		}
+		opts.Location = sl.String(strconv.Itoa(*dc.Id))
 	}
 
 	opts.Hardware, err = prepareHardwareOptions(d, meta)
@@ -411,6 +466,10 @@ func resourceSoftLayerLbVpxCreate(d *schema.ResourceData, meta interface{}) erro
 		return fmt.Errorf("Error Cannot get hardware options '%s'.", err)
 	}
 
+	for len(opts.Hardware) < quantity {
+		opts.Hardware = append(opts.Hardware, opts.Hardware[0])
+	}
+
 	log.Printf("[INFO] Creating network application delivery controller")
 
 	receipt, err := productOrderService.PlaceOrder(&opts, sl.Bool(false))
@@ -420,21 +479,42 @@ func resourceSoftLayerLbVpxCreate(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	// Wait VPX provisioning
-	VPX, err := findVPXByOrderId(*receipt.OrderId, meta)
+	VPXs, err := findVPXsByOrderId(*receipt.OrderId, quantity, meta)
 
 	if err != nil {
 		return fmt.Errorf("Error creating network application delivery controller: %s", err)
 	}
 
-	d.SetId(fmt.Sprintf("%d", *VPX.Id))
+	d.SetId(fmt.Sprintf("%d", *VPXs[0].Id))
 
 	log.Printf("[INFO] Netscaler VPX ID: %s", d.Id())
 
-	id, err := strconv.Atoi(d.Id())
-	if err != nil {
-		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	if quantity > 1 {
+		d.Set("secondary_id", *VPXs[1].Id)
+		log.Printf("[INFO] Netscaler VPX secondary ID: %d", *VPXs[1].Id)
+	}
+
+	for _, VPX := range VPXs {
+		err = waitForVPXReady(*VPX.Id, meta)
+		if err != nil {
+			return err
+		}
+	}
+
+	if quantity > 1 {
+		err = configureVPXHighAvailability(sess, *VPXs[0].Id, *VPXs[1].Id)
+		if err != nil {
+			return fmt.Errorf("Error configuring high availability of Netscaler VPX ID %d: %s", *VPXs[0].Id, err)
+		}
 	}
 
+	return resourceSoftLayerLbVpxRead(d, meta)
+}
+
+// waitForVPXReady waits until the VIPs and the REST service of a new VPX are available.
+func waitForVPXReady(id int, meta interface{}) error {
+	NADCService := services.GetNetworkApplicationDeliveryControllerService(meta.(*session.Session))
+
 	// Wait Virtual IP provisioning
 	IsVipReady := false
 
@@ -481,7 +561,60 @@ func resourceSoftLayerLbVpxCreate(d *schema.ResourceData, meta interface{}) erro
 	// Wait additional buffer time for VPX service.
 	time.Sleep(time.Second * 60)
 
-	return resourceSoftLayerLbVpxRead(d, meta)
+	return nil
+}
+
+// configureVPXHighAvailability pairs two VPXs as HA nodes. The secondary stays secondary, so the primary
+// keeps serving the VIPs.
+func configureVPXHighAvailability(sess *session.Session, primaryId int, secondaryId int) error {
+	primary, err := getNitroClient(sess, primaryId)
+	if err != nil {
+		return err
+	}
+
+	secondary, err := getNitroClient(sess, secondaryId)
+	if err != nil {
+		return err
+	}
+
+	nodes := []struct {
+		client *nitroClient
+		peer   *nitroClient
+	}{
+		{primary, secondary},
+		{secondary, primary},
+	}
+
+	for _, node := range nodes {
+		err = node.client.request("POST", "config/hanode", map[string]interface{}{
+			"hanode": map[string]interface{}{
+				"id":        1,
+				"ipaddress": node.peer.address,
+			},
+		}, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = secondary.request("PUT", "config/hanode", map[string]interface{}{
+		"hanode": map[string]interface{}{
+			"id":       0,
+			"hastatus": "STAYSECONDARY",
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		err = node.client.saveConfig()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func resourceSoftLayerLbVpxRead(d *schema.ResourceData, meta interface{}) error {
@@ -495,7 +628,7 @@ func resourceSoftLayerLbVpxRead(d *schema.ResourceData, meta interface{}) error
 
 	getObjectResult, err := service.
 		Id(id).
-		Mask("id,name,type[name],datacenter,networkVlans[primaryRouter],networkVlans[primarySubnets],subnets[ipAddresses],description").
+		Mask("id,name,type[name],datacenter,managementIpAddress,networkVlans[primaryRouter],networkVlans[primarySubnets],subnets[ipAddresses],description").
 		GetObject()
 
 	if err != nil {
@@ -546,48 +679,247 @@ func resourceSoftLayerLbVpxRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("front_end_subnet", frontEndSubnet)
 	d.Set("back_end_subnet", backEndSubnet)
 
+	// IP addresses which are added to a VPX are a new subnet, so the IP addresses of all subnets are counted.
 	vips := make([]string, 0)
-	ipCount := 0
-	for i, subnet := range getObjectResult.Subnets {
+	for _, subnet := range getObjectResult.Subnets {
 		for _, ipAddressObj := range subnet.IpAddresses {
 			vips = append(vips, *ipAddressObj.IpAddress)
-			if i == 0 {
-				ipCount++
-			}
 		}
 	}
 
 	d.Set("vip_pool", vips)
-	d.Set("ip_count", ipCount)
+	d.Set("ip_count", len(vips))
+
+	if len(getObjectResult.Subnets) > 0 && getObjectResult.Subnets[0].NetworkIdentifier != nil {
+		d.Set("vip_pool_subnet", fmt.Sprintf("%s/%d",
+			*getObjectResult.Subnets[0].NetworkIdentifier, sl.Get(getObjectResult.Subnets[0].Cidr, 0)))
+	}
 
-	description := *getObjectResult.Description
+	d.Set("management_ip_address", sl.Get(getObjectResult.ManagementIpAddress, ""))
+
+	version, speed, plan := parseVPXDescription(sl.Get(getObjectResult.Description, "").(string))
+	if speed > 0 {
+		d.Set("speed", speed)
+	}
+
+	if version != "" {
+		d.Set("version", version)
+	}
+
+	if plan != "" {
+		d.Set("plan", plan)
+	}
+
+	if secondaryId := d.Get("secondary_id").(int); secondaryId > 0 {
+		secondary, err := service.Id(secondaryId).Mask("id,managementIpAddress").GetObject()
+		if err != nil {
+			return fmt.Errorf("Error retrieving secondary network application delivery controller: %s", err)
+		}
+
+		d.Set("secondary_management_ip_address", sl.Get(secondary.ManagementIpAddress, ""))
+	}
+
+	return nil
+}
+
+// parseVPXDescription returns the version, speed and plan of a VPX from its description, e.g.
+// "Citrix NetScaler VPX 10.1 10Mbps Standard".
+func parseVPXDescription(description string) (string, int, string) {
 	r, _ := regexp.Compile(" [0-9]+Mbps")
 	speedStr := r.FindString(description)
 	r, _ = regexp.Compile("[0-9]+")
-	speed, err := strconv.Atoi(r.FindString(speedStr))
-	if err == nil && speed > 0 {
-		d.Set("speed", speed)
-	}
+	speed, _ := strconv.Atoi(r.FindString(speedStr))
 
 	r, _ = regexp.Compile(" VPX [0-9]+\\.[0-9]+ ")
 	versionStr := r.FindString(description)
 	r, _ = regexp.Compile("[0-9]+\\.[0-9]+")
 	version := r.FindString(versionStr)
-	if version != "" {
-		d.Set("version", version)
-	}
 
 	r, _ = regexp.Compile(" [A-Za-z]+$")
 	planStr := r.FindString(description)
 	r, _ = regexp.Compile("[A-Za-z]+$")
 	plan := r.FindString(planStr)
-	if plan != "" {
-		d.Set("plan", plan)
+
+	return version, speed, plan
+}
+
+func resourceSoftLayerLbVpxUpdate(d *schema.ResourceData, meta interface{}) error {
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	if d.HasChange("version") || d.HasChange("speed") || d.HasChange("plan") || d.HasChange("ip_count") {
+		// Downgrades are rejected before anything is ordered, so neither node of an HA pair is changed.
+		oldVersion, newVersion := d.GetChange("version")
+		oldSpeed, newSpeed := d.GetChange("speed")
+		oldPlan, newPlan := d.GetChange("plan")
+		oldIpCount, newIpCount := d.GetChange("ip_count")
+
+		err = checkVPXUpgrade(oldVersion.(string), newVersion.(string), oldSpeed.(int), newSpeed.(int),
+			oldPlan.(string), newPlan.(string), oldIpCount.(int), newIpCount.(int))
+		if err != nil {
+			return err
+		}
+
+		prices, err := findVPXUpgradePrices(d, meta)
+		if err != nil {
+			return fmt.Errorf("Error upgrading network application delivery controller %d: %s", id, err)
+		}
+
+		ids := []int{id}
+		if secondaryId := d.Get("secondary_id").(int); secondaryId > 0 {
+			ids = append(ids, secondaryId)
+		}
+
+		// Both nodes of an HA pair are upgraded, so they stay equal.
+		for _, nadcId := range ids {
+			err = upgradeVPX(d, meta, nadcId, prices)
+			if err != nil {
+				return fmt.Errorf("Error upgrading network application delivery controller %d: %s", nadcId, err)
+			}
+		}
+	}
+
+	return resourceSoftLayerLbVpxRead(d, meta)
+}
+
+// vpxPlanLevels orders the VPX plans, as a VPX can only be upgraded to a higher plan.
+var vpxPlanLevels = map[string]int{
+	"standard": 1,
+	"platinum": 2,
+}
+
+// checkVPXUpgrade returns an error if a change of a VPX is a downgrade, which SoftLayer can't order. The
+// version, speed, plan and IP address count of a VPX can only be increased.
+func checkVPXUpgrade(oldVersion string, newVersion string, oldSpeed int, newSpeed int,
+	oldPlan string, newPlan string, oldIpCount int, newIpCount int) error {
+	if !vpxVersionAtLeast(newVersion, oldVersion) {
+		return fmt.Errorf("version can't be decreased from %s to %s", oldVersion, newVersion)
+	}
+
+	if newSpeed < oldSpeed {
+		return fmt.Errorf("speed can't be decreased from %d to %d", oldSpeed, newSpeed)
+	}
+
+	if vpxPlanLevels[strings.ToLower(newPlan)] < vpxPlanLevels[strings.ToLower(oldPlan)] {
+		return fmt.Errorf("plan can't be downgraded from %s to %s", oldPlan, newPlan)
+	}
+
+	if newIpCount < oldIpCount {
+		return fmt.Errorf("ip_count can't be decreased from %d to %d", oldIpCount, newIpCount)
 	}
 
 	return nil
 }
 
+// findVPXUpgradePrices returns the prices of the new license and of the additional IP addresses of a VPX.
+// Additional IP addresses are ordered as a block of the added number of addresses, so the product catalog
+// decides which IP counts can be reached. Nothing is ordered if the catalog has no matching item.
+func findVPXUpgradePrices(d *schema.ResourceData, meta interface{}) ([]datatypes.Product_Item_Price, error) {
+	sess := meta.(*session.Session)
+
+	productPackage, err := product.GetPackageByType(sess, "ADDITIONAL_SERVICES_APPLICATION_DELIVERY_APPLIANCE")
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := product.GetPackageProducts(sess, *productPackage.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	keyNames := []string{}
+	if d.HasChange("version") || d.HasChange("speed") || d.HasChange("plan") {
+		keyNames = append(keyNames, getVPXPriceItemKeyName(
+			d.Get("version").(string), d.Get("speed").(int), d.Get("plan").(string)))
+	}
+
+	if d.HasChange("ip_count") {
+		oldIpCount, newIpCount := d.GetChange("ip_count")
+		keyNames = append(keyNames, getPublicIpItemKeyName(newIpCount.(int)-oldIpCount.(int)))
+	}
+
+	prices := make([]datatypes.Product_Item_Price, 0, len(keyNames))
+	for _, keyName := range keyNames {
+		var price *datatypes.Product_Item_Price
+		for _, item := range items {
+			if item.KeyName != nil && *item.KeyName == keyName && len(item.Prices) > 0 {
+				price = &datatypes.Product_Item_Price{Id: item.Prices[0].Id}
+				break
+			}
+		}
+
+		if price == nil {
+			return nil, fmt.Errorf("The product catalog has no %s item to upgrade to", keyName)
+		}
+
+		prices = append(prices, *price)
+	}
+
+	return prices, nil
+}
+
+// upgradeVPX orders the new license and the additional IP addresses of a VPX as an upgrade of the VPX, and
+// waits until the upgrade is done.
+func upgradeVPX(d *schema.ResourceData, meta interface{}, id int, prices []datatypes.Product_Item_Price) error {
+	sess := meta.(*session.Session)
+
+	version := d.Get("version").(string)
+	speed := d.Get("speed").(int)
+	plan := d.Get("plan").(string)
+	ipCount := d.Get("ip_count").(int)
+
+	log.Printf("[INFO] Upgrading Netscaler VPX ID: %d", id)
+
+	_, err := services.GetProductOrderService(sess).
+		PlaceOrder(&datatypes.Container_Product_Order_Network_Application_Delivery_Controller{
+			Container_Product_Order: datatypes.Container_Product_Order{
+				PackageId: sl.Int(PACKAGE_ID_APPLICATION_DELIVERY_CONTROLLER),
+				Prices:    prices,
+				Quantity:  sl.Int(1),
+			},
+			ApplicationDeliveryControllerId: sl.Int(id),
+		}, sl.Bool(false))
+	if err != nil {
+		return err
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			nadc, err := services.GetNetworkApplicationDeliveryControllerService(sess).
+				Id(id).
+				Mask("id,description,subnets[ipAddresses]").
+				GetObject()
+			if err != nil {
+				return nil, "", err
+			}
+
+			currentVersion, currentSpeed, currentPlan := parseVPXDescription(sl.Get(nadc.Description, "").(string))
+
+			currentIpCount := 0
+			for _, subnet := range nadc.Subnets {
+				currentIpCount += len(subnet.IpAddresses)
+			}
+
+			if currentVersion != version || currentSpeed != speed ||
+				!strings.EqualFold(currentPlan, plan) || currentIpCount < ipCount {
+				return nadc, "pending", nil
+			}
+
+			return nadc, "complete", nil
+		},
+		Timeout:    30 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	return err
+}
+
 func resourceSoftLayerLbVpxDelete(d *schema.ResourceData, meta interface{}) error {
 	sess := meta.(*session.Session)
 	service := services.GetNetworkApplicationDeliveryControllerService(sess)
@@ -597,26 +929,86 @@ func resourceSoftLayerLbVpxDelete(d *schema.ResourceData, meta interface{}) erro
 		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
 	}
 
-	billingItem, err := service.Id(id).GetBillingItem()
-	if err != nil {
-		return fmt.Errorf("Error deleting network application delivery controller: %s", err)
+	ids := []int{id}
+	if secondaryId := d.Get("secondary_id").(int); secondaryId > 0 {
+		ids = append(ids, secondaryId)
 	}
 
-	if *billingItem.Id > 0 {
-		billingItemService := services.GetBillingItemService(sess)
-		deleted, err := billingItemService.Id(*billingItem.Id).CancelService()
+	for _, nadcId := range ids {
+		billingItem, err := service.Id(nadcId).GetBillingItem()
 		if err != nil {
 			return fmt.Errorf("Error deleting network application delivery controller: %s", err)
 		}
 
-		if deleted {
-			return nil
+		if billingItem.Id == nil {
+			continue
+		}
+
+		log.Printf("[INFO] Cancelling Netscaler VPX ID: %d", nadcId)
+		_, err = services.GetBillingItemService(sess).Id(*billingItem.Id).CancelService()
+		if err != nil {
+			return fmt.Errorf("Error deleting network application delivery controller: %s", err)
 		}
 	}
 
+	d.SetId("")
 	return nil
 }
 
+// resourceSoftLayerLbVpxImport imports a VPX. The secondary node of an HA pair is found through the HA
+// configuration of the imported VPX, which is reached through NITRO.
+func resourceSoftLayerLbVpxImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	sess := meta.(*session.Session)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	client, err := getNitroClient(sess, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := struct {
+		HaNode []struct {
+			Id        nitroInt `json:"id"`
+			IpAddress string   `json:"ipaddress"`
+		} `json:"hanode"`
+	}{}
+
+	err = client.request("GET", "config/hanode", nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving the HA nodes of Netscaler VPX ID %d: %s", id, err)
+	}
+
+	// The node with ID 0 is the VPX itself, and another node is its peer.
+	d.Set("ha", false)
+	for _, node := range result.HaNode {
+		if node.Id == 0 || node.IpAddress == "" {
+			continue
+		}
+
+		peers, err := services.GetAccountService(sess).
+			Filter(filter.Path("applicationDeliveryControllers.managementIpAddress").Eq(node.IpAddress).Build()).
+			Mask("id").
+			GetApplicationDeliveryControllers()
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving the HA peer of Netscaler VPX ID %d: %s", id, err)
+		}
+
+		if len(peers) != 1 || peers[0].Id == nil {
+			return nil, fmt.Errorf("No Netscaler VPX found with the management IP address %s of the HA peer of %d",
+				node.IpAddress, id)
+		}
+
+		d.Set("ha", true)
+		d.Set("secondary_id", *peers[0].Id)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceSoftLayerLbVpxExists(d *schema.ResourceData, meta interface{}) (bool, error) {
 	service := services.GetNetworkApplicationDeliveryControllerService(meta.(*session.Session))
 
@@ -625,7 +1017,10 @@ func resourceSoftLayerLbVpxExists(d *schema.ResourceData, meta interface{}) (boo
 		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
 	}
 
-	nadc, err := service.Mask("id").Id(id).GetObject()
+	nadc, err := service.Mask("id,billingItem[id,cancellationDate]").Id(id).GetObject()
 
-	return nadc.Id != nil && *nadc.Id == id && err == nil, nil
+	// A cancelled VPX stays visible until it is reclaimed, but its billing item is cancelled. The billing
+	// item is missing for users without billing permissions, so the VPX is kept then.
+	return nadc.Id != nil && *nadc.Id == id && err == nil &&
+		(nadc.BillingItem == nil || nadc.BillingItem.CancellationDate == nil), nil
 }
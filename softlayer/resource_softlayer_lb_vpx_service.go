@@ -71,6 +71,53 @@ func resourceSoftLayerLbVpxService() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+
+			"health_monitor": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "HTTP",
+							ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+								monitorType := v.(string)
+								if monitorType != "HTTP" {
+									errors = append(errors, fmt.Errorf(
+										"Invalid health monitor type: only 'HTTP' is supported"))
+								}
+								return
+							},
+						},
+
+						"url": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "/",
+						},
+
+						"expected_code": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  200,
+						},
+
+						"interval": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  5,
+						},
+
+						"timeout": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  2,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -161,6 +208,13 @@ func resourceSoftLayerLbVpxServiceCreate(d *schema.ResourceData, meta interface{
 
 	d.SetId(fmt.Sprintf("%s:%s", vipId, serviceName))
 
+	if _, ok := d.GetOk("health_monitor"); ok {
+		err = setVpxServiceMonitor(d, sess, nadcId, serviceName)
+		if err != nil {
+			return err
+		}
+	}
+
 	return resourceSoftLayerLbVpxServiceRead(d, meta)
 }
 
@@ -185,6 +239,85 @@ func resourceSoftLayerLbVpxServiceRead(d *schema.ResourceData, meta interface{})
 	d.Set("health_check", *lbService.HealthCheck)
 	d.Set("connection_limit", *lbService.ConnectionLimit)
 
+	// The health monitor is only read through NITRO if it is managed, so services without a health monitor
+	// don't need a VPX which supports NITRO.
+	if _, ok := d.GetOk("health_monitor"); ok {
+		client, err := getNitroClientForConfig(sess, nadcId)
+		if err != nil {
+			return err
+		}
+
+		monitor, err := client.getMonitor(vpxServiceMonitorName(serviceName))
+		if isNitroNotFound(err) {
+			d.Set("health_monitor", []interface{}{})
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("Unable to get health monitor of load balancer service %s: %s", serviceName, err)
+		}
+
+		healthMonitor := map[string]interface{}{
+			"type":     monitor.Type,
+			"url":      strings.TrimPrefix(monitor.HttpRequest, "GET "),
+			"interval": int(monitor.Interval),
+			"timeout":  int(monitor.RespTimeout),
+		}
+
+		if len(monitor.RespCode) > 0 {
+			expectedCode, err := strconv.Atoi(monitor.RespCode[0])
+			if err == nil {
+				healthMonitor["expected_code"] = expectedCode
+			}
+		}
+
+		d.Set("health_monitor", []interface{}{healthMonitor})
+	}
+
+	return nil
+}
+
+// vpxServiceMonitorName returns the name of the NetScaler monitor of a service.
+func vpxServiceMonitorName(serviceName string) string {
+	return serviceName + "_monitor"
+}
+
+// setVpxServiceMonitor creates, updates or deletes the NetScaler monitor of a service, following its
+// health_monitor.
+func setVpxServiceMonitor(d *schema.ResourceData, sess *session.Session, nadcId int, serviceName string) error {
+	client, err := getNitroClientForConfig(sess, nadcId)
+	if err != nil {
+		return fmt.Errorf("Error setting health monitor of LoadBalancer Service %s: %s", serviceName, err)
+	}
+
+	monitorName := vpxServiceMonitorName(serviceName)
+
+	healthMonitors := d.Get("health_monitor").([]interface{})
+	if len(healthMonitors) == 0 {
+		log.Printf("[INFO] Deleting health monitor of LoadBalancer Service %s", serviceName)
+		err = client.deleteServiceMonitor(serviceName, monitorName)
+		if err != nil {
+			return fmt.Errorf("Error deleting health monitor of LoadBalancer Service %s: %s", serviceName, err)
+		}
+		return nil
+	}
+
+	healthMonitor := healthMonitors[0].(map[string]interface{})
+	monitor := nitroMonitor{
+		MonitorName: monitorName,
+		Type:        healthMonitor["type"].(string),
+		HttpRequest: "GET " + healthMonitor["url"].(string),
+		RespCode:    []string{strconv.Itoa(healthMonitor["expected_code"].(int))},
+		Interval:    nitroInt(healthMonitor["interval"].(int)),
+		RespTimeout: nitroInt(healthMonitor["timeout"].(int)),
+	}
+
+	log.Printf("[INFO] Setting health monitor of LoadBalancer Service %s", serviceName)
+	err = client.setServiceMonitor(serviceName, monitor)
+	if err != nil {
+		return fmt.Errorf("Error setting health monitor of LoadBalancer Service %s: %s", serviceName, err)
+	}
+
 	return nil
 }
 
@@ -239,6 +372,13 @@ func resourceSoftLayerLbVpxServiceUpdate(d *schema.ResourceData, meta interface{
 		return errors.New("Error updating LoadBalancer Service")
 	}
 
+	if d.HasChange("health_monitor") {
+		err = setVpxServiceMonitor(d, sess, nadcId, serviceName)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -249,6 +389,30 @@ func resourceSoftLayerLbVpxServiceDelete(d *schema.ResourceData, meta interface{
 	}
 
 	sess := meta.(*session.Session)
+
+	// The monitor is not removed with the service.
+	if _, ok := d.GetOk("health_monitor"); ok {
+		client, err := getNitroClientForConfig(sess, nadcId)
+		if err != nil {
+			return fmt.Errorf("Error deleting health monitor of LoadBalancer Service %s: %s", serviceName, err)
+		}
+
+		err = client.deleteServiceMonitor(serviceName, vpxServiceMonitorName(serviceName))
+		if err != nil {
+			return fmt.Errorf("Error deleting health monitor of LoadBalancer Service %s: %s", serviceName, err)
+		}
+	}
+
+	err = deleteVpxService(sess, nadcId, vipName, serviceName)
+	if err != nil {
+		return fmt.Errorf("Error deleting LoadBalancer Service %s: %s", serviceName, err)
+	}
+
+	return nil
+}
+
+// deleteVpxService deletes a service of a VIP, retrying while the VPX is busy. A missing service is ignored.
+func deleteVpxService(sess *session.Session, nadcId int, vipName string, serviceName string) error {
 	service := services.GetNetworkApplicationDeliveryControllerService(sess)
 
 	lbSvc := datatypes.Network_LoadBalancer_Service{
@@ -258,6 +422,7 @@ func resourceSoftLayerLbVpxServiceDelete(d *schema.ResourceData, meta interface{
 		},
 	}
 
+	var err error
 	for count := 0; count < 10; count++ {
 		err = service.Id(nadcId).DeleteLiveLoadBalancerService(&lbSvc)
 		log.Printf("[INFO] Deleting Loadbalancer service %s", serviceName)
@@ -280,11 +445,7 @@ func resourceSoftLayerLbVpxServiceDelete(d *schema.ResourceData, meta interface{
 		break
 	}
 
-	if err != nil {
-		return fmt.Errorf("Error deleting LoadBalancer Service %s: %s", serviceName, err)
-	}
-
-	return nil
+	return err
 }
 
 func resourceSoftLayerLbVpxServiceExists(d *schema.ResourceData, meta interface{}) (bool, error) {
@@ -0,0 +1,286 @@
+package softlayer
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/helpers/network"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const vpxServiceGroupMask = "id,name,services[name,destinationIpAddress,destinationPort,weight,healthCheck,connectionLimit]"
+
+// softlayer_lb_vpx_service_group manages all services of a VIP, so adding and changing services takes a
+// single updateLiveLoadBalancer call instead of one call per service.
+func resourceSoftLayerLbVpxServiceGroup() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerLbVpxServiceGroupCreate,
+		Read:     resourceSoftLayerLbVpxServiceGroupRead,
+		Update:   resourceSoftLayerLbVpxServiceGroupUpdate,
+		Delete:   resourceSoftLayerLbVpxServiceGroupDelete,
+		Exists:   resourceSoftLayerLbVpxServiceGroupExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"vip_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"service": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"destination_ip_address": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"destination_port": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+
+						"weight": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+
+						"connection_limit": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+
+						"health_check": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// expandVpxServices returns the services of a service group by name.
+func expandVpxServices(serviceSet *schema.Set) (map[string]datatypes.Network_LoadBalancer_Service, error) {
+	lbServices := map[string]datatypes.Network_LoadBalancer_Service{}
+
+	for _, s := range serviceSet.List() {
+		service := s.(map[string]interface{})
+		name := service["name"].(string)
+
+		if _, ok := lbServices[name]; ok {
+			return nil, fmt.Errorf("The service name '%s' is used more than once", name)
+		}
+
+		lbServices[name] = datatypes.Network_LoadBalancer_Service{
+			Name:                 sl.String(name),
+			DestinationIpAddress: sl.String(service["destination_ip_address"].(string)),
+			DestinationPort:      sl.Int(service["destination_port"].(int)),
+			Weight:               sl.Int(service["weight"].(int)),
+			ConnectionLimit:      sl.Int(service["connection_limit"].(int)),
+			HealthCheck:          sl.String(service["health_check"].(string)),
+		}
+	}
+
+	return lbServices, nil
+}
+
+// diffVpxServices compares the services of a VIP with the wanted services. It returns the names of the
+// services to remove and the services to add or change. A service with a new destination is removed and
+// added again, since the destination of a service can't be changed.
+func diffVpxServices(current []datatypes.Network_LoadBalancer_Service,
+	wanted map[string]datatypes.Network_LoadBalancer_Service) ([]string, []datatypes.Network_LoadBalancer_Service) {
+	removes := []string{}
+	updates := []datatypes.Network_LoadBalancer_Service{}
+
+	currentByName := map[string]datatypes.Network_LoadBalancer_Service{}
+	for _, service := range current {
+		if service.Name == nil {
+			continue
+		}
+
+		currentByName[*service.Name] = service
+
+		if _, ok := wanted[*service.Name]; !ok {
+			removes = append(removes, *service.Name)
+		}
+	}
+
+	for name, service := range wanted {
+		existing, ok := currentByName[name]
+		switch {
+		case !ok:
+			updates = append(updates, service)
+		case sl.Get(existing.DestinationIpAddress, "") != *service.DestinationIpAddress ||
+			sl.Get(existing.DestinationPort, 0) != *service.DestinationPort:
+			removes = append(removes, name)
+			updates = append(updates, service)
+		case sl.Get(existing.Weight, 0) != *service.Weight ||
+			sl.Get(existing.ConnectionLimit, 0) != *service.ConnectionLimit ||
+			!strings.EqualFold(sl.Get(existing.HealthCheck, "").(string), *service.HealthCheck):
+			updates = append(updates, service)
+		}
+	}
+
+	return removes, updates
+}
+
+// reconcileVpxServiceGroup makes the services of a VIP match the service group.
+func reconcileVpxServiceGroup(d *schema.ResourceData, sess *session.Session, nadcId int, vipName string) error {
+	wanted, err := expandVpxServices(d.Get("service").(*schema.Set))
+	if err != nil {
+		return err
+	}
+
+	vip, err := network.GetNadcLbVipByName(sess, nadcId, vipName, vpxServiceGroupMask)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Virtual Ip Address %s: %s", vipName, err)
+	}
+
+	removes, updates := diffVpxServices(vip.Services, wanted)
+
+	// The VPX has no bulk delete, so removed services are deleted one by one.
+	for _, serviceName := range removes {
+		err = deleteVpxService(sess, nadcId, vipName, serviceName)
+		if err != nil {
+			return fmt.Errorf("Error deleting LoadBalancer Service %s: %s", serviceName, err)
+		}
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	log.Printf("[INFO] Updating %d LoadBalancer Services of Virtual Ip Address %s", len(updates), vipName)
+
+	successFlag, err := updateVpxService(sess, nadcId, &datatypes.Network_LoadBalancer_VirtualIpAddress{
+		Name:     sl.String(vipName),
+		Services: updates,
+	})
+
+	if err != nil {
+		return fmt.Errorf("Error updating LoadBalancer Services: %s", err)
+	}
+
+	if !successFlag {
+		return errors.New("Error updating LoadBalancer Services")
+	}
+
+	return nil
+}
+
+func resourceSoftLayerLbVpxServiceGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	vipId := d.Get("vip_id").(string)
+	vipName, nadcId, _, err := parseServiceId(vipId)
+	if err != nil {
+		return fmt.Errorf("Error parsing vip id: %s", err)
+	}
+
+	err = reconcileVpxServiceGroup(d, sess, nadcId, vipName)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(vipId)
+
+	return resourceSoftLayerLbVpxServiceGroupRead(d, meta)
+}
+
+func resourceSoftLayerLbVpxServiceGroupRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	vipName, nadcId, _, err := parseServiceId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing vip id: %s", err)
+	}
+
+	vip, err := network.GetNadcLbVipByName(sess, nadcId, vipName, vpxServiceGroupMask)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Virtual Ip Address %s: %s", vipName, err)
+	}
+
+	lbServices := make([]map[string]interface{}, 0, len(vip.Services))
+	for _, service := range vip.Services {
+		lbServices = append(lbServices, map[string]interface{}{
+			"name":                   sl.Get(service.Name, ""),
+			"destination_ip_address": sl.Get(service.DestinationIpAddress, ""),
+			"destination_port":       sl.Get(service.DestinationPort, 0),
+			"weight":                 sl.Get(service.Weight, 0),
+			"connection_limit":       sl.Get(service.ConnectionLimit, 0),
+			"health_check":           sl.Get(service.HealthCheck, ""),
+		})
+	}
+
+	d.Set("vip_id", d.Id())
+	d.Set("service", lbServices)
+
+	return nil
+}
+
+func resourceSoftLayerLbVpxServiceGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	vipName, nadcId, _, err := parseServiceId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing vip id: %s", err)
+	}
+
+	if d.HasChange("service") {
+		err = reconcileVpxServiceGroup(d, sess, nadcId, vipName)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceSoftLayerLbVpxServiceGroupRead(d, meta)
+}
+
+func resourceSoftLayerLbVpxServiceGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	vipName, nadcId, _, err := parseServiceId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error parsing vip id: %s", err)
+	}
+
+	for _, s := range d.Get("service").(*schema.Set).List() {
+		serviceName := s.(map[string]interface{})["name"].(string)
+
+		err = deleteVpxService(sess, nadcId, vipName, serviceName)
+		if err != nil {
+			return fmt.Errorf("Error deleting LoadBalancer Service %s: %s", serviceName, err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerLbVpxServiceGroupExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+
+	vipName, nadcId, _, err := parseServiceId(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Error parsing vip id: %s", err)
+	}
+
+	vip, err := network.GetNadcLbVipByName(sess, nadcId, vipName, "id,name")
+
+	return vip != nil && err == nil && *vip.Name == vipName, nil
+}
@@ -0,0 +1,257 @@
+package softlayer
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/helpers/network"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func TestAccSoftLayerLbVpxServiceGroup_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerLbVpxServiceGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerLbVpxServiceGroupConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_service_group.testacc_services", "service.#", "3"),
+				),
+			},
+			{
+				Config: testAccCheckSoftLayerLbVpxServiceGroupConfig_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_service_group.testacc_services", "service.#", "2"),
+					testAccCheckSoftLayerLbVpxServiceGroupWeight(
+						"softlayer_lb_vpx_service_group.testacc_services", "test_load_balancer_service0", 60),
+				),
+			},
+		},
+	})
+}
+
+func TestDiffVpxServices(t *testing.T) {
+	current := []datatypes.Network_LoadBalancer_Service{
+		testVpxService("unchanged", "10.0.0.1", 80, 10),
+		testVpxService("weight", "10.0.0.2", 80, 10),
+		testVpxService("destination", "10.0.0.3", 80, 10),
+		testVpxService("removed", "10.0.0.4", 80, 10),
+	}
+
+	wanted := map[string]datatypes.Network_LoadBalancer_Service{
+		"unchanged":   testVpxService("unchanged", "10.0.0.1", 80, 10),
+		"weight":      testVpxService("weight", "10.0.0.2", 80, 20),
+		"destination": testVpxService("destination", "10.0.0.3", 8080, 10),
+		"added":       testVpxService("added", "10.0.0.5", 80, 10),
+	}
+
+	removes, updates := diffVpxServices(current, wanted)
+
+	updateNames := []string{}
+	for _, service := range updates {
+		updateNames = append(updateNames, *service.Name)
+	}
+
+	sort.Strings(removes)
+	sort.Strings(updateNames)
+
+	if fmt.Sprint(removes) != "[destination removed]" {
+		t.Errorf("Unexpected removes: %v", removes)
+	}
+
+	if fmt.Sprint(updateNames) != "[added destination weight]" {
+		t.Errorf("Unexpected updates: %v", updateNames)
+	}
+}
+
+func testVpxService(name string, ipAddress string, port int, weight int) datatypes.Network_LoadBalancer_Service {
+	return datatypes.Network_LoadBalancer_Service{
+		Name:                 sl.String(name),
+		DestinationIpAddress: sl.String(ipAddress),
+		DestinationPort:      sl.Int(port),
+		Weight:               sl.Int(weight),
+		ConnectionLimit:      sl.Int(5000),
+		HealthCheck:          sl.String("HTTP"),
+	}
+}
+
+func testAccCheckSoftLayerLbVpxServiceGroupWeight(n string, serviceName string, weight int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		vipName, nadcId, _, err := parseServiceId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		service, err := network.GetNadcLbVipServiceByName(
+			testAccProvider.Meta().(*session.Session), nadcId, vipName, serviceName)
+		if err != nil {
+			return err
+		}
+
+		if sl.Get(service.Weight, 0) != weight {
+			return fmt.Errorf("Expected weight %d of service %s, got %d", weight, serviceName, *service.Weight)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckSoftLayerLbVpxServiceGroupDestroy(s *terraform.State) error {
+	sess := testAccProvider.Meta().(*session.Session)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_lb_vpx_service_group" {
+			continue
+		}
+
+		vipName, nadcId, _, _ := parseServiceId(rs.Primary.ID)
+
+		vip, err := network.GetNadcLbVipByName(sess, nadcId, vipName, vpxServiceGroupMask)
+		if err != nil {
+			continue
+		}
+
+		if len(vip.Services) > 0 {
+			return fmt.Errorf("Netscaler VPX VIP %s still has %d services", vipName, len(vip.Services))
+		}
+	}
+
+	return nil
+}
+
+var testAccCheckSoftLayerLbVpxServiceGroupConfig_basic = `
+
+resource "softlayer_virtual_guest" "vm" {
+    count = 3
+    name = "vm${count.index}"
+    domain = "example.com"
+    image = "DEBIAN_7_64"
+    datacenter = "wdc01"
+    public_network_speed = 10
+    hourly_billing = true
+    private_network_only = false
+    cpu = 1
+    ram = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_lb_vpx" "testacc_vpx" {
+    datacenter = "wdc01"
+    speed = 10
+    version = "10.1"
+    plan = "Standard"
+    ip_count = 2
+}
+
+resource "softlayer_lb_vpx_vip" "testacc_vip" {
+    name = "test_load_balancer_vip"
+    nad_controller_id = "${softlayer_lb_vpx.testacc_vpx.id}"
+    load_balancing_method = "lc"
+    source_port = 80
+    type = "HTTP"
+    virtual_ip_address = "${softlayer_lb_vpx.testacc_vpx.vip_pool[0]}"
+}
+
+resource "softlayer_lb_vpx_service_group" "testacc_services" {
+  vip_id = "${softlayer_lb_vpx_vip.testacc_vip.id}"
+
+  service {
+    name = "test_load_balancer_service0"
+    destination_ip_address = "${softlayer_virtual_guest.vm.0.ipv4_address}"
+    destination_port = 80
+    weight = 55
+    connection_limit = 5000
+    health_check = "HTTP"
+  }
+
+  service {
+    name = "test_load_balancer_service1"
+    destination_ip_address = "${softlayer_virtual_guest.vm.1.ipv4_address}"
+    destination_port = 80
+    weight = 55
+    connection_limit = 5000
+    health_check = "HTTP"
+  }
+
+  service {
+    name = "test_load_balancer_service2"
+    destination_ip_address = "${softlayer_virtual_guest.vm.2.ipv4_address}"
+    destination_port = 80
+    weight = 55
+    connection_limit = 5000
+    health_check = "HTTP"
+  }
+}
+`
+
+var testAccCheckSoftLayerLbVpxServiceGroupConfig_update = `
+
+resource "softlayer_virtual_guest" "vm" {
+    count = 3
+    name = "vm${count.index}"
+    domain = "example.com"
+    image = "DEBIAN_7_64"
+    datacenter = "wdc01"
+    public_network_speed = 10
+    hourly_billing = true
+    private_network_only = false
+    cpu = 1
+    ram = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_lb_vpx" "testacc_vpx" {
+    datacenter = "wdc01"
+    speed = 10
+    version = "10.1"
+    plan = "Standard"
+    ip_count = 2
+}
+
+resource "softlayer_lb_vpx_vip" "testacc_vip" {
+    name = "test_load_balancer_vip"
+    nad_controller_id = "${softlayer_lb_vpx.testacc_vpx.id}"
+    load_balancing_method = "lc"
+    source_port = 80
+    type = "HTTP"
+    virtual_ip_address = "${softlayer_lb_vpx.testacc_vpx.vip_pool[0]}"
+}
+
+resource "softlayer_lb_vpx_service_group" "testacc_services" {
+  vip_id = "${softlayer_lb_vpx_vip.testacc_vip.id}"
+
+  service {
+    name = "test_load_balancer_service0"
+    destination_ip_address = "${softlayer_virtual_guest.vm.0.ipv4_address}"
+    destination_port = 80
+    weight = 60
+    connection_limit = 5000
+    health_check = "HTTP"
+  }
+
+  service {
+    name = "test_load_balancer_service1"
+    destination_ip_address = "${softlayer_virtual_guest.vm.1.ipv4_address}"
+    destination_port = 80
+    weight = 55
+    connection_limit = 5000
+    health_check = "HTTP"
+  }
+}
+`
@@ -32,6 +32,32 @@ func TestAccSoftLayerLbVpxService_Basic(t *testing.T) {
 	})
 }
 
+func TestAccSoftLayerLbVpxService_HealthMonitor(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerLbVpxServiceConfig_healthMonitor,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_service.testacc_service", "health_monitor.#", "1"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_service.testacc_service", "health_monitor.0.type", "HTTP"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_service.testacc_service", "health_monitor.0.url", "/health"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_service.testacc_service", "health_monitor.0.expected_code", "200"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_service.testacc_service", "health_monitor.0.interval", "10"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_service.testacc_service", "health_monitor.0.timeout", "3"),
+				),
+			},
+		},
+	})
+}
+
 var testAccCheckSoftLayerLbVpxServiceConfig_basic = `
 
 resource "softlayer_virtual_guest" "vm1" {
@@ -99,3 +125,53 @@ resource "softlayer_lb_vpx_service" "testacc_service2" {
   health_check = "HTTP"
 }
 `
+
+var testAccCheckSoftLayerLbVpxServiceConfig_healthMonitor = `
+
+resource "softlayer_virtual_guest" "vm1" {
+    name = "vm1"
+    domain = "example.com"
+    image = "DEBIAN_7_64"
+    datacenter = "wdc01"
+    public_network_speed = 10
+    hourly_billing = true
+    private_network_only = false
+    cpu = 1
+    ram = 1024
+    disks = [25]
+    local_disk = false
+}
+
+resource "softlayer_lb_vpx" "testacc_vpx" {
+    datacenter = "wdc01"
+    speed = 10
+    version = "10.5"
+    plan = "Standard"
+    ip_count = 2
+}
+
+resource "softlayer_lb_vpx_vip" "testacc_vip" {
+    name = "test_load_balancer_vip"
+    nad_controller_id = "${softlayer_lb_vpx.testacc_vpx.id}"
+    load_balancing_method = "lc"
+    source_port = 80
+    type = "HTTP"
+    virtual_ip_address = "${softlayer_lb_vpx.testacc_vpx.vip_pool[0]}"
+}
+
+resource "softlayer_lb_vpx_service" "testacc_service" {
+  name = "test_load_balancer_service"
+  vip_id = "${softlayer_lb_vpx_vip.testacc_vip.id}"
+  destination_ip_address = "${softlayer_virtual_guest.vm1.ipv4_address}"
+  destination_port = 80
+  weight = 55
+  connection_limit = 5000
+  health_check = "HTTP"
+  health_monitor {
+    url = "/health"
+    expected_code = 200
+    interval = 10
+    timeout = 3
+  }
+}
+`
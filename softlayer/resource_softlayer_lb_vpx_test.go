@@ -49,12 +49,77 @@ func TestAccSoftLayerLbVpx_Basic(t *testing.T) {
 						"softlayer_lb_vpx.testacc_foobar_vpx", "back_end_subnet", "10.107.180.0/26"),
 					resource.TestCheckResourceAttr(
 						"softlayer_lb_vpx.testacc_foobar_vpx", "vip_pool.#", "2"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_lb_vpx.testacc_foobar_vpx", "vip_pool_subnet"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_lb_vpx.testacc_foobar_vpx", "management_ip_address"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerLbVpxConfig_upgrade,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerLbVpxExists("softlayer_lb_vpx.testacc_foobar_vpx", &nadc),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx.testacc_foobar_vpx", "speed", "200"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx.testacc_foobar_vpx", "ip_count", "4"),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx.testacc_foobar_vpx", "vip_pool.#", "4"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccSoftLayerLbVpx_HA(t *testing.T) {
+	var nadc datatypes.Network_Application_Delivery_Controller
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerLbVpxConfig_ha,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerLbVpxExists("softlayer_lb_vpx.testacc_ha_vpx", &nadc),
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx.testacc_ha_vpx", "ha", "true"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_lb_vpx.testacc_ha_vpx", "secondary_id"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_lb_vpx.testacc_ha_vpx", "management_ip_address"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_lb_vpx.testacc_ha_vpx", "secondary_management_ip_address"),
+				),
+			},
+		},
+	})
+}
+
+func TestCheckVPXUpgrade(t *testing.T) {
+	if err := checkVPXUpgrade("10.1", "10.5", 10, 200, "Standard", "Platinum", 2, 4); err != nil {
+		t.Errorf("Expected an upgrade to be allowed: %s", err)
+	}
+
+	if err := checkVPXUpgrade("10.5", "10.5", 200, 200, "Platinum", "Platinum", 4, 4); err != nil {
+		t.Errorf("Expected an unchanged VPX to be allowed: %s", err)
+	}
+
+	downgrades := map[string]error{
+		"version":  checkVPXUpgrade("10.5", "10.1", 10, 10, "Standard", "Standard", 2, 2),
+		"speed":    checkVPXUpgrade("10.1", "10.1", 200, 10, "Standard", "Standard", 2, 2),
+		"plan":     checkVPXUpgrade("10.1", "10.1", 10, 10, "Platinum", "Standard", 2, 2),
+		"ip_count": checkVPXUpgrade("10.1", "10.1", 10, 10, "Standard", "Standard", 4, 2),
+	}
+
+	for name, err := range downgrades {
+		if err == nil {
+			t.Errorf("Expected a downgrade of %s to be rejected", name)
+		}
+	}
+}
+
 func testAccCheckSoftLayerLbVpxExists(n string, nadc *datatypes.Network_Application_Delivery_Controller) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -103,3 +168,32 @@ resource "softlayer_lb_vpx" "testacc_foobar_vpx" {
     front_end_subnet = "192.155.224.208/28"
     back_end_subnet = "10.107.180.0/26"
 }`
+
+const testAccCheckSoftLayerLbVpxConfig_upgrade = `
+resource "softlayer_lb_vpx" "testacc_foobar_vpx" {
+    datacenter = "dal06"
+    speed = 200
+    version = "10.1"
+    plan = "Standard"
+    ip_count = 4
+    front_end_vlan {
+       vlan_number = 1251
+       primary_router_hostname = "fcr01a.dal06"
+    }
+    back_end_vlan {
+       vlan_number = 1540
+       primary_router_hostname = "bcr01a.dal06"
+    }
+    front_end_subnet = "192.155.224.208/28"
+    back_end_subnet = "10.107.180.0/26"
+}`
+
+const testAccCheckSoftLayerLbVpxConfig_ha = `
+resource "softlayer_lb_vpx" "testacc_ha_vpx" {
+    datacenter = "dal06"
+    speed = 10
+    version = "10.1"
+    plan = "Standard"
+    ip_count = 2
+    ha = true
+}`
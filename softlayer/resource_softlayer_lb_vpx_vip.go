@@ -61,6 +61,24 @@ func resourceSoftLayerLbVpxVip() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+
+			"persistence": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					persistence := v.(string)
+					if _, ok := vpxPersistenceTypes[persistence]; !ok {
+						errors = append(errors, fmt.Errorf(
+							"Invalid persistence: persistence should be 'sourceip' or 'cookie'"))
+					}
+					return
+				},
+			},
+
+			"security_certificate_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
 		},
 	}
 }
@@ -99,13 +117,40 @@ func resourceSoftLayerLbVpxVipCreate(d *schema.ResourceData, meta interface{}) e
 		VirtualIpAddress:    sl.String(d.Get("virtual_ip_address").(string)),
 	}
 
+	// SSL is offloaded to the VPX with the certificate.
+	if certificateId, ok := d.GetOk("security_certificate_id"); ok {
+		template.SecurityCertificateId = sl.Int(certificateId.(int))
+	}
+
+	err := createVpxVip(service, nadcId, &template)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%d:%s", nadcId, vipName))
+
+	log.Printf("[INFO] Netscaler VPX VIP ID: %s", d.Id())
+
+	if persistence, ok := d.GetOk("persistence"); ok {
+		err = setVpxVipPersistence(sess, nadcId, vipName, persistence.(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceSoftLayerLbVpxVipRead(d, meta)
+}
+
+// createVpxVip creates the VIP of a VPX, retrying while another operation is in progress.
+func createVpxVip(service services.Network_Application_Delivery_Controller, nadcId int,
+	template *datatypes.Network_LoadBalancer_VirtualIpAddress) error {
 	log.Printf("[INFO] Creating Virtual Ip Address %s", *template.VirtualIpAddress)
 
 	var err error
 	var successFlag bool
 
 	for count := 0; count < 10; count++ {
-		successFlag, err = service.Id(nadcId).CreateLiveLoadBalancer(&template)
+		successFlag, err = service.Id(nadcId).CreateLiveLoadBalancer(template)
 		log.Printf("[INFO] Creating Virtual Ip Address %s successFlag : %t", *template.VirtualIpAddress, successFlag)
 
 		if err != nil && strings.Contains(err.Error(), "already exists") {
@@ -132,11 +177,7 @@ func resourceSoftLayerLbVpxVipCreate(d *schema.ResourceData, meta interface{}) e
 		return errors.New("Error creating Virtual Ip Address")
 	}
 
-	d.SetId(fmt.Sprintf("%d:%s", nadcId, vipName))
-
-	log.Printf("[INFO] Netscaler VPX VIP ID: %s", d.Id())
-
-	return resourceSoftLayerLbVpxVipRead(d, meta)
+	return nil
 }
 
 func resourceSoftLayerLbVpxVipRead(d *schema.ResourceData, meta interface{}) error {
@@ -173,6 +214,41 @@ func resourceSoftLayerLbVpxVipRead(d *schema.ResourceData, meta interface{}) err
 		d.Set("virtual_ip_address", *vip.VirtualIpAddress)
 	}
 
+	d.Set("security_certificate_id", sl.Get(vip.SecurityCertificateId, 0))
+
+	// The persistence is only read through NITRO if it is managed, so VIPs without persistence don't need
+	// a VPX which supports NITRO.
+	if _, ok := d.GetOk("persistence"); ok {
+		client, err := getNitroClientForConfig(sess, nadcId)
+		if err != nil {
+			return fmt.Errorf("softlayer_lb_vpx : %s", err)
+		}
+
+		persistence, err := client.getPersistence(vipName)
+		if err != nil {
+			return fmt.Errorf("softlayer_lb_vpx : while looking up the persistence of %s : %s", vipName, err)
+		}
+
+		d.Set("persistence", persistence)
+	}
+
+	return nil
+}
+
+// setVpxVipPersistence sets the persistence of the NetScaler virtual server of a VIP.
+func setVpxVipPersistence(sess *session.Session, nadcId int, vipName string, persistence string) error {
+	client, err := getNitroClientForConfig(sess, nadcId)
+	if err != nil {
+		return fmt.Errorf("Error setting persistence of Virtual Ip Address %s: %s", vipName, err)
+	}
+
+	log.Printf("[INFO] Setting persistence of Virtual Ip Address %s to '%s'", vipName, persistence)
+
+	err = client.setPersistence(vipName, persistence)
+	if err != nil {
+		return fmt.Errorf("Error setting persistence of Virtual Ip Address %s: %s", vipName, err)
+	}
+
 	return nil
 }
 
@@ -181,8 +257,9 @@ func resourceSoftLayerLbVpxVipUpdate(d *schema.ResourceData, meta interface{}) e
 	service := services.GetNetworkApplicationDeliveryControllerService(sess)
 
 	nadcId := d.Get("nad_controller_id").(int)
+	vipName := d.Get("name").(string)
 	template := datatypes.Network_LoadBalancer_VirtualIpAddress{
-		Name: sl.String(d.Get("name").(string)),
+		Name: sl.String(vipName),
 	}
 
 	if d.HasChange("load_balancing_method") {
@@ -195,27 +272,77 @@ func resourceSoftLayerLbVpxVipUpdate(d *schema.ResourceData, meta interface{}) e
 
 	var err error
 
-	for count := 0; count < 10; count++ {
-		var successFlag bool
-		successFlag, err = service.Id(nadcId).UpdateLiveLoadBalancer(&template)
-		log.Printf("[INFO]  Updating Virtual Ip Address %s successFlag : %t", *template.VirtualIpAddress, successFlag)
+	// The SSL offload of a VIP can't be turned off, so the VIP is recreated without the certificate.
+	certificateId := d.Get("security_certificate_id").(int)
+	if d.HasChange("security_certificate_id") && certificateId == 0 {
+		template.LoadBalancingMethod = sl.String(d.Get("load_balancing_method").(string))
+		template.SourcePort = sl.Int(d.Get("source_port").(int))
+		template.Type = sl.String(d.Get("type").(string))
+		template.VirtualIpAddress = sl.String(d.Get("virtual_ip_address").(string))
 
-		if err != nil && strings.Contains(err.Error(), "Operation already in progress") {
-			log.Printf("[INFO] Updating Virtual Ip Address %s error : %s. Retry in 10 secs", *template.VirtualIpAddress, err.Error())
-			time.Sleep(time.Second * 10)
-			continue
+		err = recreateVpxVip(service, nadcId, &template)
+		if err != nil {
+			return err
 		}
 
-		break
+		if persistence, ok := d.GetOk("persistence"); ok {
+			err = setVpxVipPersistence(sess, nadcId, vipName, persistence.(string))
+			if err != nil {
+				return err
+			}
+		}
+
+		return resourceSoftLayerLbVpxVipRead(d, meta)
 	}
 
-	if err != nil {
-		return fmt.Errorf("Error updating Virtual Ip Address: %s", err)
+	if d.HasChange("security_certificate_id") {
+		template.SecurityCertificateId = sl.Int(certificateId)
+	}
+
+	if d.HasChange("load_balancing_method") || d.HasChange("virtual_ip_address") ||
+		d.HasChange("security_certificate_id") {
+		for count := 0; count < 10; count++ {
+			var successFlag bool
+			successFlag, err = service.Id(nadcId).UpdateLiveLoadBalancer(&template)
+			log.Printf("[INFO]  Updating Virtual Ip Address %s successFlag : %t", vipName, successFlag)
+
+			if err != nil && strings.Contains(err.Error(), "Operation already in progress") {
+				log.Printf("[INFO] Updating Virtual Ip Address %s error : %s. Retry in 10 secs", vipName, err.Error())
+				time.Sleep(time.Second * 10)
+				continue
+			}
+
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("Error updating Virtual Ip Address: %s", err)
+		}
+	}
+
+	if d.HasChange("persistence") {
+		err = setVpxVipPersistence(sess, nadcId, vipName, d.Get("persistence").(string))
+		if err != nil {
+			return err
+		}
 	}
 
 	return resourceSoftLayerLbVpxVipRead(d, meta)
 }
 
+// recreateVpxVip deletes the VIP of a VPX and creates it again from the template.
+func recreateVpxVip(service services.Network_Application_Delivery_Controller, nadcId int,
+	template *datatypes.Network_LoadBalancer_VirtualIpAddress) error {
+	log.Printf("[INFO] Recreating Virtual Ip Address %s", *template.Name)
+
+	err := deleteVpxVip(service, nadcId, *template.Name)
+	if err != nil {
+		return err
+	}
+
+	return createVpxVip(service, nadcId, template)
+}
+
 func resourceSoftLayerLbVpxVipDelete(d *schema.ResourceData, meta interface{}) error {
 	sess := meta.(*session.Session)
 	service := services.GetNetworkApplicationDeliveryControllerService(sess)
@@ -225,6 +352,14 @@ func resourceSoftLayerLbVpxVipDelete(d *schema.ResourceData, meta interface{}) e
 		return fmt.Errorf("softlayer_lb_vpx : %s", err)
 	}
 
+	return deleteVpxVip(service, nadcId, vipName)
+}
+
+// deleteVpxVip deletes the VIP of a VPX, retrying while another operation is in progress. A missing VIP is
+// ignored.
+func deleteVpxVip(service services.Network_Application_Delivery_Controller, nadcId int, vipName string) error {
+	var err error
+
 	for count := 0; count < 10; count++ {
 		var successFlag bool
 		successFlag, err = service.Id(nadcId).DeleteLiveLoadBalancer(
@@ -8,8 +8,11 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 
+	"github.com/softlayer/softlayer-go/datatypes"
 	"github.com/softlayer/softlayer-go/helpers/network"
+	"github.com/softlayer/softlayer-go/services"
 	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
 )
 
 func TestAccSoftLayerLbVpxVip_Basic(t *testing.T) {
@@ -35,6 +38,117 @@ func TestAccSoftLayerLbVpxVip_Basic(t *testing.T) {
 	})
 }
 
+func TestAccSoftLayerLbVpxVip_Persistence(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerLbVpxVipDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckSoftLayerLbVpxVipConfig_persistence, "sourceip"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_vip.testacc_vip", "persistence", "sourceip"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckSoftLayerLbVpxVipConfig_persistence, "cookie"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_vip.testacc_vip", "persistence", "cookie"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSoftLayerLbVpxVip_SecurityCertificate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerLbVpxVipDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerSecurityCertificateConfig_basic +
+					fmt.Sprintf(testAccCheckSoftLayerLbVpxVipConfig_securityCertificate,
+						"security_certificate_id = \"${softlayer_security_certificate.test-cert.id}\""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerLbVpxVipCertificate(
+						"softlayer_lb_vpx_vip.testacc_vip", "softlayer_security_certificate.test-cert"),
+				),
+			},
+			{
+				Config: testAccCheckSoftLayerSecurityCertificateConfig_basic +
+					fmt.Sprintf(testAccCheckSoftLayerLbVpxVipConfig_securityCertificate, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_lb_vpx_vip.testacc_vip", "security_certificate_id", "0"),
+				),
+			},
+		},
+	})
+}
+
+// Removing the certificate of a VIP recreates the VIP without the certificate, which turns off SSL offload.
+func TestRecreateVpxVip(t *testing.T) {
+	var calls []string
+	var created *datatypes.Network_LoadBalancer_VirtualIpAddress
+
+	sess := &session.Session{
+		TransportHandler: func(sess *session.Session, service string, method string, args []interface{},
+			options *sl.Options, pResult interface{}) error {
+			calls = append(calls, method)
+			if method == "createLiveLoadBalancer" {
+				created = args[0].(*datatypes.Network_LoadBalancer_VirtualIpAddress)
+			}
+			*pResult.(*bool) = true
+			return nil
+		},
+	}
+
+	template := datatypes.Network_LoadBalancer_VirtualIpAddress{
+		LoadBalancingMethod: sl.String("lc"),
+		Name:                sl.String("test_load_balancer_vip"),
+		SourcePort:          sl.Int(443),
+		Type:                sl.String("HTTP"),
+		VirtualIpAddress:    sl.String("10.0.0.10"),
+	}
+
+	err := recreateVpxVip(services.GetNetworkApplicationDeliveryControllerService(sess), 123, &template)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(calls) != 2 || calls[0] != "deleteLiveLoadBalancer" || calls[1] != "createLiveLoadBalancer" {
+		t.Fatalf("Unexpected calls: %v", calls)
+	}
+
+	if created.SecurityCertificateId != nil || *created.SourcePort != 443 {
+		t.Fatalf("Unexpected VIP: %+v", created)
+	}
+}
+
+func testAccCheckSoftLayerLbVpxVipCertificate(vipName string, certificateName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		vip, ok := s.RootModule().Resources[vipName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", vipName)
+		}
+
+		certificate, ok := s.RootModule().Resources[certificateName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", certificateName)
+		}
+
+		if vip.Primary.Attributes["security_certificate_id"] != certificate.Primary.ID {
+			return fmt.Errorf("Security certificate of %s is %s instead of %s",
+				vipName, vip.Primary.Attributes["security_certificate_id"], certificate.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckSoftLayerLbVpxVipDestroy(s *terraform.State) error {
 	sess := testAccProvider.Meta().(*session.Session)
 
@@ -76,3 +190,43 @@ resource "softlayer_lb_vpx_vip" "testacc_vip" {
     virtual_ip_address = "${softlayer_lb_vpx.testacc_foobar_nadc.vip_pool[0]}"
 }
 `
+
+var testAccCheckSoftLayerLbVpxVipConfig_persistence = `
+resource "softlayer_lb_vpx" "testacc_foobar_nadc" {
+    datacenter = "dal09"
+    speed = 10
+    version = "10.5"
+    plan = "Standard"
+    ip_count = 2
+}
+
+resource "softlayer_lb_vpx_vip" "testacc_vip" {
+    name = "test_load_balancer_vip"
+    nad_controller_id = "${softlayer_lb_vpx.testacc_foobar_nadc.id}"
+    load_balancing_method = "lc"
+    source_port = 80
+    type = "HTTP"
+    virtual_ip_address = "${softlayer_lb_vpx.testacc_foobar_nadc.vip_pool[0]}"
+    persistence = "%s"
+}
+`
+
+var testAccCheckSoftLayerLbVpxVipConfig_securityCertificate = `
+resource "softlayer_lb_vpx" "testacc_foobar_nadc" {
+    datacenter = "dal09"
+    speed = 10
+    version = "10.5"
+    plan = "Standard"
+    ip_count = 2
+}
+
+resource "softlayer_lb_vpx_vip" "testacc_vip" {
+    name = "test_load_balancer_vip"
+    nad_controller_id = "${softlayer_lb_vpx.testacc_foobar_nadc.id}"
+    load_balancing_method = "lc"
+    source_port = 443
+    type = "HTTP"
+    virtual_ip_address = "${softlayer_lb_vpx.testacc_foobar_nadc.vip_pool[0]}"
+    %s
+}
+`
@@ -0,0 +1,554 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+// networkMonitorQueryTypes maps the symbolic query types to the words of the SoftLayer query type
+// names. A query type matches when its name contains all of the included words and none of the
+// excluded words.
+var networkMonitorQueryTypes = map[string]struct {
+	include []string
+	exclude []string
+}{
+	"PING":         {include: []string{"PING"}, exclude: []string{"SLOW"}},
+	"SLOW_PING":    {include: []string{"SLOW", "PING"}},
+	"HTTP":         {include: []string{"HTTP"}, exclude: []string{"CONTENT"}},
+	"HTTP_CONTENT": {include: []string{"HTTP", "CONTENT"}},
+	"DNS":          {include: []string{"DNS"}},
+	"SMTP":         {include: []string{"SMTP"}},
+	"CUSTOM_PORT":  {include: []string{"PORT"}},
+}
+
+// networkMonitorResponseActions maps the symbolic response actions to a matcher of the SoftLayer
+// response type action descriptions.
+var networkMonitorResponseActions = map[string]func(description string) bool{
+	"NOTIFY_USERS": func(description string) bool {
+		return strings.Contains(description, "NOTIFY") && !strings.Contains(description, "REBOOT")
+	},
+	"REBOOT": func(description string) bool {
+		return strings.Contains(description, "REBOOT")
+	},
+	"NONE": func(description string) bool {
+		return strings.Contains(description, "NOTHING")
+	},
+}
+
+// The query types and response types of the monitoring stratum don't change, so they are only retrieved once.
+var (
+	networkMonitorQueryTypesCache    []datatypes.Network_Monitor_Version1_Query_Type
+	networkMonitorResponseTypesCache []datatypes.Network_Monitor_Version1_Query_ResponseType
+	networkMonitorTypesMutex         sync.Mutex
+)
+
+func getNetworkMonitorQueryTypes(sess *session.Session) ([]datatypes.Network_Monitor_Version1_Query_Type, error) {
+	networkMonitorTypesMutex.Lock()
+	defer networkMonitorTypesMutex.Unlock()
+
+	if networkMonitorQueryTypesCache == nil {
+		queryTypes, err := services.GetNetworkMonitorVersion1QueryHostStratumService(sess).GetAllQueryTypes()
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving monitoring query types: %s", err)
+		}
+
+		networkMonitorQueryTypesCache = queryTypes
+	}
+
+	return networkMonitorQueryTypesCache, nil
+}
+
+func getNetworkMonitorResponseTypes(sess *session.Session) ([]datatypes.Network_Monitor_Version1_Query_ResponseType, error) {
+	networkMonitorTypesMutex.Lock()
+	defer networkMonitorTypesMutex.Unlock()
+
+	if networkMonitorResponseTypesCache == nil {
+		responseTypes, err := services.GetNetworkMonitorVersion1QueryHostStratumService(sess).GetAllResponseTypes()
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving monitoring response types: %s", err)
+		}
+
+		networkMonitorResponseTypesCache = responseTypes
+	}
+
+	return networkMonitorResponseTypesCache, nil
+}
+
+func resourceSoftLayerNetworkMonitor() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerNetworkMonitorCreate,
+		Read:     resourceSoftLayerNetworkMonitorRead,
+		Update:   resourceSoftLayerNetworkMonitorUpdate,
+		Delete:   resourceSoftLayerNetworkMonitorDelete,
+		Exists:   resourceSoftLayerNetworkMonitorExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"guest_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"hardware_id"},
+			},
+
+			"hardware_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"guest_id"},
+			},
+
+			"ip_address": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"query_type": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"query_argument": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"response_action": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "NOTIFY_USERS",
+			},
+
+			"wait_cycles": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"notified_users": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+
+			"query_type_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"response_action_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// getNetworkMonitorQueryType resolves a symbolic query type, or the name of a SoftLayer query type,
+// among the query types available through the monitoring stratum. It also validates the query
+// argument against the argument description of the query type.
+func getNetworkMonitorQueryType(sess *session.Session, queryType string, argument string) (
+	datatypes.Network_Monitor_Version1_Query_Type, error) {
+	queryTypes, err := getNetworkMonitorQueryTypes(sess)
+	if err != nil {
+		return datatypes.Network_Monitor_Version1_Query_Type{}, err
+	}
+
+	var result *datatypes.Network_Monitor_Version1_Query_Type
+	for i, qt := range queryTypes {
+		name := strings.ToUpper(sl.Get(qt.Name, "").(string))
+
+		if strings.EqualFold(name, queryType) {
+			result = &queryTypes[i]
+			break
+		}
+
+		words, ok := networkMonitorQueryTypes[strings.ToUpper(queryType)]
+		if !ok || result != nil {
+			continue
+		}
+
+		matches := true
+		for _, word := range words.include {
+			matches = matches && strings.Contains(name, word)
+		}
+		for _, word := range words.exclude {
+			matches = matches && !strings.Contains(name, word)
+		}
+
+		if matches {
+			result = &queryTypes[i]
+		}
+	}
+
+	if result == nil {
+		return datatypes.Network_Monitor_Version1_Query_Type{},
+			fmt.Errorf("No monitoring query type matching %s could be found", queryType)
+	}
+
+	argumentDescription := sl.Get(result.ArgumentDescription, "").(string)
+	if argumentDescription != "" && argument == "" {
+		return datatypes.Network_Monitor_Version1_Query_Type{},
+			fmt.Errorf("Query type %s requires query_argument: %s", *result.Name, argumentDescription)
+	}
+
+	if argumentDescription == "" && argument != "" {
+		return datatypes.Network_Monitor_Version1_Query_Type{},
+			fmt.Errorf("Query type %s doesn't accept query_argument", *result.Name)
+	}
+
+	return *result, nil
+}
+
+// getNetworkMonitorResponseActionId resolves a symbolic response action, or a SoftLayer response
+// type id, among the response types available through the monitoring stratum.
+func getNetworkMonitorResponseActionId(sess *session.Session, responseAction string) (int, error) {
+	responseTypes, err := getNetworkMonitorResponseTypes(sess)
+	if err != nil {
+		return 0, err
+	}
+
+	matcher, ok := networkMonitorResponseActions[strings.ToUpper(responseAction)]
+	for _, rt := range responseTypes {
+		if strconv.Itoa(*rt.Id) == responseAction {
+			return *rt.Id, nil
+		}
+
+		if ok && matcher(strings.ToUpper(sl.Get(rt.ActionDescription, "").(string))) {
+			return *rt.Id, nil
+		}
+	}
+
+	return 0, fmt.Errorf("No monitoring response action matching %s could be found", responseAction)
+}
+
+func getNetworkMonitorServerIpAddress(d *schema.ResourceData, sess *session.Session) (string, error) {
+	if guestId, ok := d.GetOk("guest_id"); ok {
+		guest, err := services.GetVirtualGuestService(sess).Id(guestId.(int)).GetObject()
+		if err != nil {
+			return "", fmt.Errorf("Error looking up virtual guest %d: %s", guestId.(int), err)
+		}
+
+		if guest.PrimaryIpAddress == nil {
+			return "", fmt.Errorf(
+				"No primary ip address found for virtual guest %d. Please specify it.", guestId.(int))
+		}
+
+		return *guest.PrimaryIpAddress, nil
+	}
+
+	hardwareId := d.Get("hardware_id").(int)
+	hardware, err := services.GetHardwareService(sess).Id(hardwareId).GetObject()
+	if err != nil {
+		return "", fmt.Errorf("Error looking up hardware %d: %s", hardwareId, err)
+	}
+
+	if hardware.PrimaryIpAddress == nil {
+		return "", fmt.Errorf(
+			"No primary ip address found for hardware %d. Please specify it.", hardwareId)
+	}
+
+	return *hardware.PrimaryIpAddress, nil
+}
+
+func resourceSoftLayerNetworkMonitorCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	monitorService := services.GetNetworkMonitorVersion1QueryHostService(sess)
+
+	// A monitor without a server monitors any IP address, e.g. a VIP of a load balancer. Such a monitor can't
+	// notify users or reboot a server.
+	guestId, hasGuest := d.GetOk("guest_id")
+	hardwareId, hasHardware := d.GetOk("hardware_id")
+	if !hasGuest && !hasHardware {
+		if d.Get("ip_address").(string) == "" {
+			return fmt.Errorf("Error creating network monitor: one of guest_id, hardware_id or ip_address is required")
+		}
+
+		if len(d.Get("notified_users").([]interface{})) > 0 {
+			return fmt.Errorf("Error creating network monitor: notified_users requires guest_id or hardware_id")
+		}
+
+		if strings.EqualFold(d.Get("response_action").(string), "REBOOT") {
+			return fmt.Errorf("Error creating network monitor: the REBOOT response action requires guest_id or hardware_id")
+		}
+	}
+
+	ipAddress := d.Get("ip_address").(string)
+	if ipAddress == "" {
+		var err error
+		ipAddress, err = getNetworkMonitorServerIpAddress(d, sess)
+		if err != nil {
+			return err
+		}
+	}
+
+	queryType, err := getNetworkMonitorQueryType(sess, d.Get("query_type").(string), d.Get("query_argument").(string))
+	if err != nil {
+		return fmt.Errorf("Error creating network monitor: %s", err)
+	}
+
+	responseActionId, err := getNetworkMonitorResponseActionId(sess, d.Get("response_action").(string))
+	if err != nil {
+		return fmt.Errorf("Error creating network monitor: %s", err)
+	}
+
+	// Build up our creation options
+	opts := datatypes.Network_Monitor_Version1_Query_Host{
+		IpAddress:        sl.String(ipAddress),
+		QueryTypeId:      queryType.Id,
+		ResponseActionId: sl.Int(responseActionId),
+	}
+
+	if hasGuest {
+		opts.GuestId = sl.Int(guestId.(int))
+	} else if hasHardware {
+		opts.HardwareId = sl.Int(hardwareId.(int))
+	}
+
+	if argument, ok := d.GetOk("query_argument"); ok {
+		opts.Arg1Value = sl.String(argument.(string))
+	}
+
+	if waitCycles, ok := d.GetOk("wait_cycles"); ok {
+		opts.WaitCycles = sl.Int(waitCycles.(int))
+	}
+
+	res, err := monitorService.CreateObject(&opts)
+	if err != nil {
+		return fmt.Errorf("Error creating network monitor: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(*res.Id))
+	log.Printf("[INFO] Network Monitor Id: %d", *res.Id)
+
+	err = createNetworkMonitorNotifications(d, meta)
+	if err != nil {
+		return err
+	}
+
+	return resourceSoftLayerNetworkMonitorRead(d, meta)
+}
+
+// createNetworkMonitorNotifications links the notified users to the monitored server. Links which
+// already exist are left as they are.
+func createNetworkMonitorNotifications(d *schema.ResourceData, meta interface{}) error {
+	if guestId, ok := d.GetOk("guest_id"); ok {
+		return createNotifications(d, meta, guestId.(int))
+	}
+
+	sess := meta.(*session.Session)
+	hardwareId := d.Get("hardware_id").(int)
+	if hardwareId == 0 {
+		if len(d.Get("notified_users").([]interface{})) > 0 {
+			return fmt.Errorf("Error updating network monitor: notified_users requires guest_id or hardware_id")
+		}
+
+		return nil
+	}
+
+	notificationLinks, err := services.GetHardwareServerService(sess).Id(hardwareId).GetMonitoringUserNotification()
+	if err != nil {
+		return fmt.Errorf("Error looking up user notifications for hardware %d", hardwareId)
+	}
+
+	existing := []int{}
+	for _, link := range notificationLinks {
+		existing = append(existing, *link.UserId)
+	}
+
+	notificationService := services.GetUserCustomerNotificationHardwareService(sess)
+	for _, userId := range d.Get("notified_users").([]interface{}) {
+		if contains(existing, userId.(int)) {
+			continue
+		}
+
+		_, err = notificationService.CreateObject(&datatypes.User_Customer_Notification_Hardware{
+			HardwareId: sl.Int(hardwareId),
+			UserId:     sl.Int(userId.(int)),
+		})
+		if err != nil {
+			return fmt.Errorf("Error creating user notification for hardware %d: %s", hardwareId, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceSoftLayerNetworkMonitorRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkMonitorVersion1QueryHostService(sess)
+
+	monitorId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	monitor, err := service.Id(monitorId).Mask("id,guestId,hardwareId,ipAddress,arg1Value,queryTypeId," +
+		"queryType[name],responseActionId,waitCycles,status").GetObject()
+	if err != nil {
+		// If the monitor is somehow already destroyed, mark as
+		// succesfully gone
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("Error retrieving network monitor: %s", err)
+	}
+
+	d.Set("ip_address", strings.TrimSpace(sl.Get(monitor.IpAddress, "").(string)))
+	d.Set("query_argument", sl.Get(monitor.Arg1Value, ""))
+	d.Set("query_type_id", sl.Get(monitor.QueryTypeId, 0))
+	d.Set("response_action_id", sl.Get(monitor.ResponseActionId, 0))
+	d.Set("wait_cycles", sl.Get(monitor.WaitCycles, 0))
+	d.Set("status", sl.Get(monitor.Status, ""))
+
+	// Keep the configured symbolic names unless the monitor was changed outside of terraform
+	if monitor.QueryType != nil && monitor.QueryType.Name != nil {
+		queryTypeName := d.Get("query_type").(string)
+		queryType, err := getNetworkMonitorQueryType(sess, queryTypeName, sl.Get(monitor.Arg1Value, "").(string))
+		if err != nil || *queryType.Id != *monitor.QueryTypeId {
+			d.Set("query_type", *monitor.QueryType.Name)
+		}
+	}
+
+	responseActionId, err := getNetworkMonitorResponseActionId(sess, d.Get("response_action").(string))
+	if err != nil || responseActionId != sl.Get(monitor.ResponseActionId, 0).(int) {
+		d.Set("response_action", strconv.Itoa(sl.Get(monitor.ResponseActionId, 0).(int)))
+	}
+
+	var notificationUserIds []int
+	if monitor.GuestId != nil {
+		d.Set("guest_id", *monitor.GuestId)
+
+		notificationLinks, err := services.GetVirtualGuestService(sess).Id(*monitor.GuestId).GetMonitoringUserNotification()
+		if err != nil {
+			return fmt.Errorf("Error looking up user notifications for virtual guest %d", *monitor.GuestId)
+		}
+
+		for _, link := range notificationLinks {
+			if !contains(notificationUserIds, *link.UserId) {
+				notificationUserIds = append(notificationUserIds, *link.UserId)
+			}
+		}
+	} else if monitor.HardwareId != nil {
+		d.Set("hardware_id", *monitor.HardwareId)
+
+		notificationLinks, err := services.GetHardwareServerService(sess).Id(*monitor.HardwareId).GetMonitoringUserNotification()
+		if err != nil {
+			return fmt.Errorf("Error looking up user notifications for hardware %d", *monitor.HardwareId)
+		}
+
+		for _, link := range notificationLinks {
+			if !contains(notificationUserIds, *link.UserId) {
+				notificationUserIds = append(notificationUserIds, *link.UserId)
+			}
+		}
+	}
+	d.Set("notified_users", notificationUserIds)
+
+	return nil
+}
+
+func resourceSoftLayerNetworkMonitorUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkMonitorVersion1QueryHostService(sess)
+
+	monitorId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	monitor, err := service.Id(monitorId).GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving network monitor: %s", err)
+	}
+
+	if d.HasChange("ip_address") {
+		monitor.IpAddress = sl.String(d.Get("ip_address").(string))
+	}
+
+	if d.HasChange("query_type") || d.HasChange("query_argument") {
+		queryType, err := getNetworkMonitorQueryType(sess, d.Get("query_type").(string), d.Get("query_argument").(string))
+		if err != nil {
+			return fmt.Errorf("Error updating network monitor: %s", err)
+		}
+
+		monitor.QueryTypeId = queryType.Id
+		monitor.Arg1Value = sl.String(d.Get("query_argument").(string))
+	}
+
+	if d.HasChange("response_action") {
+		responseActionId, err := getNetworkMonitorResponseActionId(sess, d.Get("response_action").(string))
+		if err != nil {
+			return fmt.Errorf("Error updating network monitor: %s", err)
+		}
+
+		monitor.ResponseActionId = sl.Int(responseActionId)
+	}
+
+	if d.HasChange("wait_cycles") {
+		monitor.WaitCycles = sl.Int(d.Get("wait_cycles").(int))
+	}
+
+	_, err = service.Id(monitorId).EditObject(&monitor)
+	if err != nil {
+		return fmt.Errorf("Error editing network monitor: %s", err)
+	}
+
+	// Will only create notification objects for user/server relationships that
+	// don't exist yet.
+	err = createNetworkMonitorNotifications(d, meta)
+	if err != nil {
+		return err
+	}
+
+	return resourceSoftLayerNetworkMonitorRead(d, meta)
+}
+
+func resourceSoftLayerNetworkMonitorDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkMonitorVersion1QueryHostService(sess)
+
+	monitorId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	log.Printf("[INFO] Deleting network monitor: %d", monitorId)
+	_, err = service.Id(monitorId).DeleteObject()
+	if err != nil {
+		return fmt.Errorf("Error deleting network monitor: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerNetworkMonitorExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+	service := services.GetNetworkMonitorVersion1QueryHostService(sess)
+
+	monitorId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	result, err := service.Id(monitorId).Mask("id").GetObject()
+	return err == nil && result.Id != nil && *result.Id == monitorId, nil
+}
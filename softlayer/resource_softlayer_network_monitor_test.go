@@ -0,0 +1,153 @@
+package softlayer
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerNetworkMonitor_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerNetworkMonitorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerNetworkMonitorConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerNetworkMonitorExists("softlayer_network_monitor.testacc_foobar"),
+					resource.TestCheckResourceAttr(
+						"softlayer_network_monitor.testacc_foobar", "guest_id", "22274327"),
+					resource.TestCheckResourceAttr(
+						"softlayer_network_monitor.testacc_foobar", "ip_address", "169.54.168.102"),
+					resource.TestCheckResourceAttr(
+						"softlayer_network_monitor.testacc_foobar", "query_type", "PING"),
+					resource.TestCheckResourceAttr(
+						"softlayer_network_monitor.testacc_foobar", "response_action", "NOTIFY_USERS"),
+					resource.TestCheckResourceAttr(
+						"softlayer_network_monitor.testacc_foobar", "wait_cycles", "5"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_network_monitor.testacc_foobar", "query_type_id"),
+				),
+			},
+
+			{
+				Config: testAccCheckSoftLayerNetworkMonitorConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerNetworkMonitorExists("softlayer_network_monitor.testacc_foobar"),
+					resource.TestCheckResourceAttr(
+						"softlayer_network_monitor.testacc_foobar", "query_type", "HTTP_CONTENT"),
+					resource.TestCheckResourceAttr(
+						"softlayer_network_monitor.testacc_foobar", "query_argument", "healthy"),
+					resource.TestCheckResourceAttr(
+						"softlayer_network_monitor.testacc_foobar", "response_action", "REBOOT"),
+					resource.TestCheckResourceAttr(
+						"softlayer_network_monitor.testacc_foobar", "wait_cycles", "10"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSoftLayerNetworkMonitor_IpAddress(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerNetworkMonitorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSoftLayerNetworkMonitorConfig_ipAddress,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerNetworkMonitorExists("softlayer_network_monitor.testacc_vip"),
+					resource.TestCheckResourceAttr(
+						"softlayer_network_monitor.testacc_vip", "ip_address", "169.54.168.103"),
+					resource.TestCheckResourceAttr(
+						"softlayer_network_monitor.testacc_vip", "query_type", "HTTP"),
+					resource.TestCheckResourceAttr(
+						"softlayer_network_monitor.testacc_vip", "response_action", "NONE"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerNetworkMonitorDestroy(s *terraform.State) error {
+	service := services.GetNetworkMonitorVersion1QueryHostService(testAccProvider.Meta().(*session.Session))
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_network_monitor" {
+			continue
+		}
+
+		monitorId, _ := strconv.Atoi(rs.Primary.ID)
+
+		// Try to find the network monitor
+		_, err := service.Id(monitorId).GetObject()
+
+		if err == nil {
+			return errors.New("Network Monitor still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckSoftLayerNetworkMonitorExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return errors.New("No Record ID is set")
+		}
+
+		monitorId, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := services.GetNetworkMonitorVersion1QueryHostService(testAccProvider.Meta().(*session.Session))
+		monitor, err := service.Id(monitorId).GetObject()
+
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(*monitor.Id) != rs.Primary.ID {
+			return errors.New("Record not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerNetworkMonitorConfig_basic = `
+resource "softlayer_network_monitor" "testacc_foobar" {
+    guest_id = 22274327
+    ip_address = "169.54.168.102"
+    query_type = "PING"
+    wait_cycles = 5
+}`
+
+const testAccCheckSoftLayerNetworkMonitorConfig_updated = `
+resource "softlayer_network_monitor" "testacc_foobar" {
+    guest_id = 22274327
+    ip_address = "169.54.168.102"
+    query_type = "HTTP_CONTENT"
+    query_argument = "healthy"
+    response_action = "REBOOT"
+    wait_cycles = 10
+}`
+
+const testAccCheckSoftLayerNetworkMonitorConfig_ipAddress = `
+resource "softlayer_network_monitor" "testacc_vip" {
+    ip_address = "169.54.168.103"
+    query_type = "HTTP"
+    response_action = "NONE"
+}`
@@ -3,7 +3,6 @@ package softlayer
 import (
 	"fmt"
 	"log"
-
 	"time"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -38,18 +37,85 @@ func resourceSoftLayerObjectStorageAccount() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"adopt_existing": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"clusters": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"datacenter": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"public_auth_url": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"private_auth_url": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"username": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"api_key": &schema.Schema{
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
+const ObjectStorageAccountMask = "id,username,notes,billingItem[id]," +
+	"storageNodes[datacenter[name]]"
+
+// getObjectStorageAccount returns the object storage account with the given name, or nil if there is no
+// such account. The account is read as SoftLayer_Network_Storage_Hub_Swift, which has the storage nodes
+// of each cluster.
+func getObjectStorageAccount(sess *session.Session, accountName string) (*datatypes.Network_Storage_Hub_Swift, error) {
+	accounts := []datatypes.Network_Storage_Hub_Swift{}
+
+	service := services.GetAccountService(sess).
+		Mask(ObjectStorageAccountMask).
+		Filter(filter.Path("hubNetworkStorage.username").Eq(accountName).Build())
+	err := sess.DoRequest("SoftLayer_Account", "getHubNetworkStorage", nil, &service.Options, &accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, account := range accounts {
+		if account.Username != nil && *account.Username == accountName {
+			return &account, nil
+		}
+	}
+
+	return nil, nil
+}
+
 func resourceSoftLayerObjectStorageAccountCreate(d *schema.ResourceData, meta interface{}) error {
 	sess := meta.(*session.Session)
 	accountService := services.GetAccountService(sess)
 
-	// Check if an object storage account exists
-	objectStorageAccounts, err := accountService.GetHubNetworkStorage()
-	if err != nil {
-		return fmt.Errorf("resource_softlayer_objectstorage_account: Error on create: %s", err)
+	// Existing accounts are only adopted when asked to, since the resource cancels the account on destroy.
+	var objectStorageAccounts []datatypes.Network_Storage
+	if d.Get("adopt_existing").(bool) {
+		var err error
+		objectStorageAccounts, err = accountService.Filter(
+			filter.Path("hubNetworkStorage.billingItem.id").NotNull().Build(),
+		).GetHubNetworkStorage()
+		if err != nil {
+			return fmt.Errorf("resource_softlayer_objectstorage_account: Error on create: %s", err)
+		}
 	}
 
 	if len(objectStorageAccounts) == 0 {
@@ -92,7 +158,16 @@ func resourceSoftLayerObjectStorageAccountCreate(d *schema.ResourceData, meta in
 	d.SetId(*objectStorageAccounts[0].Username)
 	d.Set("name", *objectStorageAccounts[0].Username)
 
-	return nil
+	if note, ok := d.GetOk("local_note"); ok {
+		_, err := services.GetNetworkStorageService(sess).
+			Id(*objectStorageAccounts[0].Id).
+			EditObject(&datatypes.Network_Storage{Notes: sl.String(note.(string))})
+		if err != nil {
+			return fmt.Errorf("resource_softlayer_objectstorage_account: Error setting note: %s", err)
+		}
+	}
+
+	return resourceSoftLayerObjectStorageAccountRead(d, meta)
 }
 
 func WaitForOrderCompletion(
@@ -131,39 +206,93 @@ func WaitForOrderCompletion(
 
 func resourceSoftLayerObjectStorageAccountRead(d *schema.ResourceData, meta interface{}) error {
 	sess := meta.(*session.Session)
-	accountService := services.GetAccountService(sess)
 	accountName := d.Id()
-	d.Set("name", accountName)
 
-	// Check if an object storage account exists
-	objectStorageAccounts, err := accountService.Filter(
-		filter.Path("username").Eq(accountName).Build(),
-	).GetHubNetworkStorage()
+	account, err := getObjectStorageAccount(sess, accountName)
 	if err != nil {
 		return fmt.Errorf("resource_softlayer_objectstorage_account: Error on Read: %s", err)
 	}
 
-	for _, objectStorageAccount := range objectStorageAccounts {
-		if *objectStorageAccount.Username == accountName {
-			return nil
+	if account == nil {
+		return fmt.Errorf("resource_softlayer_objectstorage_account: Could not find account %s", accountName)
+	}
+
+	d.Set("name", accountName)
+	d.Set("local_note", sl.Get(account.Notes, ""))
+
+	// Swift authenticates with the account name and the SoftLayer username, using the SoftLayer API key.
+	clusters := make([]map[string]interface{}, 0, len(account.StorageNodes))
+	for _, node := range account.StorageNodes {
+		if node.Datacenter == nil || node.Datacenter.Name == nil {
+			continue
 		}
+
+		datacenter := *node.Datacenter.Name
+		clusters = append(clusters, map[string]interface{}{
+			"datacenter":       datacenter,
+			"public_auth_url":  getObjectStorageAuthURL(datacenter),
+			"private_auth_url": getObjectStoragePrivateAuthURL(datacenter),
+			"username":         accountName + ":" + sess.UserName,
+			"api_key":          sess.APIKey,
+		})
 	}
 
-	return fmt.Errorf("resource_softlayer_objectstorage_account: Could not find account %s", accountName)
+	d.Set("clusters", clusters)
+
+	return nil
 }
 
 func resourceSoftLayerObjectStorageAccountUpdate(d *schema.ResourceData, meta interface{}) error {
-	// Nothing to update for now. Not supported.
-	return nil
+	sess := meta.(*session.Session)
+
+	if d.HasChange("local_note") {
+		account, err := getObjectStorageAccount(sess, d.Id())
+		if err != nil || account == nil {
+			return fmt.Errorf("resource_softlayer_objectstorage_account: Could not find account %s", d.Id())
+		}
+
+		_, err = services.GetNetworkStorageService(sess).
+			Id(*account.Id).
+			EditObject(&datatypes.Network_Storage{Notes: sl.String(d.Get("local_note").(string))})
+		if err != nil {
+			return fmt.Errorf("resource_softlayer_objectstorage_account: Error setting note: %s", err)
+		}
+	}
+
+	return resourceSoftLayerObjectStorageAccountRead(d, meta)
 }
 
 func resourceSoftLayerObjectStorageAccountDelete(d *schema.ResourceData, meta interface{}) error {
-	// Delete is not supported for now.
+	sess := meta.(*session.Session)
+
+	account, err := getObjectStorageAccount(sess, d.Id())
+	if err != nil {
+		return fmt.Errorf("resource_softlayer_objectstorage_account: Error on Delete: %s", err)
+	}
+
+	// The account was already cancelled.
+	if account == nil || account.BillingItem == nil || account.BillingItem.Id == nil {
+		d.SetId("")
+		return nil
+	}
+
+	log.Printf("[INFO] Cancelling object storage account: %s", d.Id())
+	_, err = services.GetBillingItemService(sess).Id(*account.BillingItem.Id).CancelService()
+	if err != nil {
+		return fmt.Errorf("resource_softlayer_objectstorage_account: Error cancelling account: %s", err)
+	}
+
+	d.SetId("")
 	return nil
 }
 
 func resourceSoftLayerObjectStorageAccountExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	err := resourceSoftLayerObjectStorageAccountRead(d, meta)
+	sess := meta.(*session.Session)
+
+	account, err := getObjectStorageAccount(sess, d.Id())
+	if err != nil {
+		return false, fmt.Errorf("resource_softlayer_objectstorage_account: Error on Exists: %s", err)
+	}
 
-	return err == nil, err
+	return account != nil && account.BillingItem != nil, nil
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/session"
 )
 
 func TestAccSoftLayerObjectStorageAccount_Basic(t *testing.T) {
@@ -21,6 +22,22 @@ func TestAccSoftLayerObjectStorageAccount_Basic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckSoftLayerObjectStorageAccountExists("softlayer_objectstorage_account.testacc_foobar", &accountName),
 					testAccCheckSoftLayerObjectStorageAccountAttributes(&accountName),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_account.testacc_foobar", "local_note", "terraform test"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_objectstorage_account.testacc_foobar", "clusters.0.public_auth_url"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_objectstorage_account.testacc_foobar", "clusters.0.username"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_objectstorage_account.testacc_foobar", "clusters.0.api_key"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerObjectStorageAccountConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_account.testacc_foobar", "local_note", "terraform test updated"),
 				),
 			},
 		},
@@ -28,6 +45,23 @@ func TestAccSoftLayerObjectStorageAccount_Basic(t *testing.T) {
 }
 
 func testAccCheckSoftLayerObjectStorageAccountDestroy(s *terraform.State) error {
+	sess := testAccProvider.Meta().(*session.Session)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_objectstorage_account" {
+			continue
+		}
+
+		account, err := getObjectStorageAccount(sess, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if account != nil && account.BillingItem != nil {
+			return fmt.Errorf("Object storage account %s still exists", rs.Primary.ID)
+		}
+	}
+
 	return nil
 }
 
@@ -62,4 +96,10 @@ func testAccCheckSoftLayerObjectStorageAccountAttributes(accountName *string) re
 
 var testAccCheckSoftLayerObjectStorageAccountConfig_basic = `
 resource "softlayer_objectstorage_account" "testacc_foobar" {
+    local_note = "terraform test"
+}`
+
+var testAccCheckSoftLayerObjectStorageAccountConfig_updated = `
+resource "softlayer_objectstorage_account" "testacc_foobar" {
+    local_note = "terraform test updated"
 }`
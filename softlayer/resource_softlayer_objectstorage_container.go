@@ -0,0 +1,200 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func resourceSoftLayerObjectStorageContainer() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSoftLayerObjectStorageContainerCreate,
+		Read:   resourceSoftLayerObjectStorageContainerRead,
+		Update: resourceSoftLayerObjectStorageContainerUpdate,
+		Delete: resourceSoftLayerObjectStorageContainerDelete,
+		Exists: resourceSoftLayerObjectStorageContainerExists,
+
+		Schema: map[string]*schema.Schema{
+			"account_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"datacenter": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  ObjectStorageDefaultDatacenter,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					name := v.(string)
+					if name == "" || strings.Contains(name, "/") || len(name) > 256 {
+						errors = append(errors, fmt.Errorf(
+							"Invalid name: name should be 1-256 characters long without '/'"))
+					}
+					return
+				},
+			},
+
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+
+			"read_acl": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"write_acl": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"object_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"bytes_used": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func getObjectStorageContainerClient(d *schema.ResourceData, meta interface{}) (*swiftClient, error) {
+	return getSwiftClient(meta.(*session.Session), d.Get("account_name").(string), d.Get("datacenter").(string))
+}
+
+// getObjectStorageContainerHeaders returns the headers which set the metadata and the ACLs of a
+// container. Metadata and ACLs which were removed from the configuration are removed explicitly,
+// because Swift keeps metadata which isn't sent.
+func getObjectStorageContainerHeaders(d *schema.ResourceData) map[string]string {
+	headers := map[string]string{}
+
+	oldMetadata, newMetadata := d.GetChange("metadata")
+	for key := range oldMetadata.(map[string]interface{}) {
+		if _, ok := newMetadata.(map[string]interface{})[key]; !ok {
+			headers["X-Remove-Container-Meta-"+key] = "x"
+		}
+	}
+
+	for key, value := range newMetadata.(map[string]interface{}) {
+		headers["X-Container-Meta-"+key] = value.(string)
+	}
+
+	for attribute, header := range map[string]string{"read_acl": "Container-Read", "write_acl": "Container-Write"} {
+		if acl := d.Get(attribute).(string); acl != "" {
+			headers["X-"+header] = acl
+		} else {
+			headers["X-Remove-"+header] = "x"
+		}
+	}
+
+	return headers
+}
+
+func resourceSoftLayerObjectStorageContainerCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getObjectStorageContainerClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+
+	log.Printf("[INFO] Creating object storage container: %s", name)
+	_, err = client.Do("PUT", name, getObjectStorageContainerHeaders(d), nil)
+	if err != nil {
+		return fmt.Errorf("Error creating object storage container: %s", err)
+	}
+
+	d.SetId(name)
+
+	return resourceSoftLayerObjectStorageContainerRead(d, meta)
+}
+
+func resourceSoftLayerObjectStorageContainerRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := getObjectStorageContainerClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	headers, err := client.Do("HEAD", d.Id(), nil, nil)
+	if err != nil {
+		return fmt.Errorf("Error retrieving object storage container: %s", err)
+	}
+
+	d.Set("name", d.Id())
+	metadata := getSwiftMetadata(headers, "X-Container-Meta-")
+	d.Set("metadata", keepSwiftMetadataCase(metadata, d.Get("metadata").(map[string]interface{})))
+	d.Set("read_acl", headers.Get("X-Container-Read"))
+	d.Set("write_acl", headers.Get("X-Container-Write"))
+	setSwiftIntHeader(d, "object_count", headers, "X-Container-Object-Count")
+	setSwiftIntHeader(d, "bytes_used", headers, "X-Container-Bytes-Used")
+
+	return nil
+}
+
+func resourceSoftLayerObjectStorageContainerUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getObjectStorageContainerClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Do("POST", d.Id(), getObjectStorageContainerHeaders(d), nil)
+	if err != nil {
+		return fmt.Errorf("Error updating object storage container: %s", err)
+	}
+
+	return resourceSoftLayerObjectStorageContainerRead(d, meta)
+}
+
+func resourceSoftLayerObjectStorageContainerDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := getObjectStorageContainerClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	// Swift refuses to delete containers which still have objects.
+	log.Printf("[INFO] Deleting object storage container: %s", d.Id())
+	_, err = client.Do("DELETE", d.Id(), nil, nil)
+	if err != nil && !isSwiftNotFound(err) {
+		return fmt.Errorf("Error deleting object storage container: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerObjectStorageContainerExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	client, err := getObjectStorageContainerClient(d, meta)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = client.Do("HEAD", d.Id(), nil, nil)
+	if isSwiftNotFound(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func setSwiftIntHeader(d *schema.ResourceData, attribute string, headers http.Header, name string) {
+	var value int
+	if _, err := fmt.Sscanf(headers.Get(name), "%d", &value); err == nil {
+		d.Set(attribute, value)
+	}
+}
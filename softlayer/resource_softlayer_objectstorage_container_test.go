@@ -0,0 +1,94 @@
+package softlayer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerObjectStorageContainer_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerObjectStorageContainerDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerObjectStorageContainerConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.testacc_container", "name", "testacc-container"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.testacc_container", "metadata.%", "1"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.testacc_container", "metadata.owner", "terraform"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.testacc_container", "read_acl", ".r:*"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerObjectStorageContainerConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.testacc_container", "metadata.%", "1"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.testacc_container", "metadata.Stage", "test"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_container.testacc_container", "read_acl", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerObjectStorageContainerDestroy(s *terraform.State) error {
+	sess := testAccProvider.Meta().(*session.Session)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_objectstorage_container" {
+			continue
+		}
+
+		client, err := getSwiftClient(sess, rs.Primary.Attributes["account_name"], rs.Primary.Attributes["datacenter"])
+		if err != nil {
+			return err
+		}
+
+		_, err = client.Do("HEAD", rs.Primary.ID, nil, nil)
+		if !isSwiftNotFound(err) {
+			return fmt.Errorf("Object storage container %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckSoftLayerObjectStorageContainerConfig_basic = `
+resource "softlayer_objectstorage_account" "testacc_account" {
+}
+
+resource "softlayer_objectstorage_container" "testacc_container" {
+    account_name = "${softlayer_objectstorage_account.testacc_account.name}"
+    name = "testacc-container"
+    metadata {
+        owner = "terraform"
+    }
+    read_acl = ".r:*"
+}
+`
+
+const testAccCheckSoftLayerObjectStorageContainerConfig_updated = `
+resource "softlayer_objectstorage_account" "testacc_account" {
+}
+
+resource "softlayer_objectstorage_container" "testacc_container" {
+    account_name = "${softlayer_objectstorage_account.testacc_account.name}"
+    name = "testacc-container"
+    metadata {
+        Stage = "test"
+    }
+}
+`
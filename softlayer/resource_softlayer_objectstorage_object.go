@@ -0,0 +1,182 @@
+package softlayer
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func resourceSoftLayerObjectStorageObject() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSoftLayerObjectStorageObjectPut,
+		Read:   resourceSoftLayerObjectStorageObjectRead,
+		Update: resourceSoftLayerObjectStorageObjectPut,
+		Delete: resourceSoftLayerObjectStorageObjectDelete,
+		Exists: resourceSoftLayerObjectStorageObjectExists,
+
+		Schema: map[string]*schema.Schema{
+			"account_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"datacenter": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  ObjectStorageDefaultDatacenter,
+			},
+
+			"container": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"content": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"source"},
+			},
+
+			"source": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"content"},
+			},
+
+			"content_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"etag": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func getObjectStorageObjectClient(d *schema.ResourceData, meta interface{}) (*swiftClient, error) {
+	return getSwiftClient(meta.(*session.Session), d.Get("account_name").(string), d.Get("datacenter").(string))
+}
+
+// getObjectStorageObjectData returns the data to be uploaded, which is either the content or the
+// content of the source file.
+func getObjectStorageObjectData(d *schema.ResourceData) ([]byte, error) {
+	if source, ok := d.GetOk("source"); ok {
+		return ioutil.ReadFile(source.(string))
+	}
+
+	return []byte(d.Get("content").(string)), nil
+}
+
+func getMD5(data []byte) string {
+	hash := md5.Sum(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func resourceSoftLayerObjectStorageObjectPut(d *schema.ResourceData, meta interface{}) error {
+	client, err := getObjectStorageObjectClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	data, err := getObjectStorageObjectData(d)
+	if err != nil {
+		return fmt.Errorf("Error reading object storage object source: %s", err)
+	}
+
+	path := d.Get("container").(string) + "/" + d.Get("name").(string)
+
+	// Swift verifies the ETag, so corrupted uploads are rejected.
+	headers := map[string]string{
+		"ETag": getMD5(data),
+	}
+
+	if contentType, ok := d.GetOk("content_type"); ok {
+		headers["Content-Type"] = contentType.(string)
+	}
+
+	log.Printf("[INFO] Uploading object storage object: %s", path)
+	_, err = client.Do("PUT", path, headers, data)
+	if err != nil {
+		return fmt.Errorf("Error uploading object storage object: %s", err)
+	}
+
+	d.SetId(path)
+
+	return resourceSoftLayerObjectStorageObjectRead(d, meta)
+}
+
+func resourceSoftLayerObjectStorageObjectRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := getObjectStorageObjectClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	headers, err := client.Do("HEAD", d.Id(), nil, nil)
+	if err != nil {
+		return fmt.Errorf("Error retrieving object storage object: %s", err)
+	}
+
+	etag := headers.Get("ETag")
+	d.Set("etag", etag)
+	d.Set("content_type", headers.Get("Content-Type"))
+
+	// The object was changed outside of Terraform, or the source file was changed, when the ETag
+	// doesn't match the data. Clearing the data attribute makes Terraform upload it again.
+	if data, err := getObjectStorageObjectData(d); err != nil || getMD5(data) != etag {
+		if _, ok := d.GetOk("source"); ok {
+			d.Set("source", "")
+		} else {
+			d.Set("content", "")
+		}
+	}
+
+	return nil
+}
+
+func resourceSoftLayerObjectStorageObjectDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := getObjectStorageObjectClient(d, meta)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting object storage object: %s", d.Id())
+	_, err = client.Do("DELETE", d.Id(), nil, nil)
+	if err != nil && !isSwiftNotFound(err) {
+		return fmt.Errorf("Error deleting object storage object: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerObjectStorageObjectExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	client, err := getObjectStorageObjectClient(d, meta)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = client.Do("HEAD", d.Id(), nil, nil)
+	if isSwiftNotFound(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
@@ -0,0 +1,99 @@
+package softlayer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerObjectStorageObject_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerObjectStorageObjectDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerObjectStorageObjectConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_object.testacc_object", "content", "hello"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_object.testacc_object", "content_type", "text/plain"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_object.testacc_object", "etag", getMD5([]byte("hello"))),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerObjectStorageObjectConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_object.testacc_object", "content", "hello again"),
+					resource.TestCheckResourceAttr(
+						"softlayer_objectstorage_object.testacc_object", "etag", getMD5([]byte("hello again"))),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerObjectStorageObjectDestroy(s *terraform.State) error {
+	sess := testAccProvider.Meta().(*session.Session)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_objectstorage_object" {
+			continue
+		}
+
+		client, err := getSwiftClient(sess, rs.Primary.Attributes["account_name"], rs.Primary.Attributes["datacenter"])
+		if err != nil {
+			return err
+		}
+
+		_, err = client.Do("HEAD", rs.Primary.ID, nil, nil)
+		if !isSwiftNotFound(err) {
+			return fmt.Errorf("Object storage object %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckSoftLayerObjectStorageObjectConfig_basic = `
+resource "softlayer_objectstorage_account" "testacc_account" {
+}
+
+resource "softlayer_objectstorage_container" "testacc_container" {
+    account_name = "${softlayer_objectstorage_account.testacc_account.name}"
+    name = "testacc-object-container"
+}
+
+resource "softlayer_objectstorage_object" "testacc_object" {
+    account_name = "${softlayer_objectstorage_account.testacc_account.name}"
+    container = "${softlayer_objectstorage_container.testacc_container.name}"
+    name = "greeting.txt"
+    content = "hello"
+    content_type = "text/plain"
+}
+`
+
+const testAccCheckSoftLayerObjectStorageObjectConfig_updated = `
+resource "softlayer_objectstorage_account" "testacc_account" {
+}
+
+resource "softlayer_objectstorage_container" "testacc_container" {
+    account_name = "${softlayer_objectstorage_account.testacc_account.name}"
+    name = "testacc-object-container"
+}
+
+resource "softlayer_objectstorage_object" "testacc_object" {
+    account_name = "${softlayer_objectstorage_account.testacc_account.name}"
+    container = "${softlayer_objectstorage_container.testacc_container.name}"
+    name = "greeting.txt"
+    content = "hello again"
+    content_type = "text/plain"
+}
+`
@@ -0,0 +1,318 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+// The vendored softlayer-go does not include the SoftLayer_Network_SecurityGroup service yet.
+// The types and calls below follow the generated services in softlayer-go/services so that they
+// can be replaced by the generated ones once the dependency is updated.
+
+type Network_SecurityGroup struct {
+	Id          *int                                     `json:"id,omitempty"`
+	Name        *string                                  `json:"name,omitempty"`
+	Description *string                                  `json:"description,omitempty"`
+	Rules       []Network_SecurityGroup_Rule             `json:"rules,omitempty"`
+	Bindings    []Network_SecurityGroup_ComponentBinding `json:"networkComponentBindings,omitempty"`
+}
+
+type Network_SecurityGroup_Rule struct {
+	Id              *int    `json:"id,omitempty"`
+	SecurityGroupId *int    `json:"securityGroupId,omitempty"`
+	Direction       *string `json:"direction,omitempty"`
+	Ethertype       *string `json:"ethertype,omitempty"`
+	PortRangeMin    *int    `json:"portRangeMin,omitempty"`
+	PortRangeMax    *int    `json:"portRangeMax,omitempty"`
+	Protocol        *string `json:"protocol,omitempty"`
+	RemoteGroupId   *int    `json:"remoteGroupId,omitempty"`
+	RemoteIp        *string `json:"remoteIp,omitempty"`
+}
+
+type Network_SecurityGroup_ComponentBinding struct {
+	Id                 *int `json:"id,omitempty"`
+	NetworkComponentId *int `json:"networkComponentId,omitempty"`
+	SecurityGroupId    *int `json:"securityGroupId,omitempty"`
+}
+
+type Network_SecurityGroup_Service struct {
+	Session *session.Session
+	Options sl.Options
+}
+
+func GetNetworkSecurityGroupService(sess *session.Session) Network_SecurityGroup_Service {
+	return Network_SecurityGroup_Service{Session: sess}
+}
+
+func (r Network_SecurityGroup_Service) Id(id int) Network_SecurityGroup_Service {
+	r.Options.Id = &id
+	return r
+}
+
+func (r Network_SecurityGroup_Service) Mask(mask string) Network_SecurityGroup_Service {
+	if !strings.HasPrefix(mask, "mask[") && (strings.Contains(mask, "[") || strings.Contains(mask, ",")) {
+		mask = fmt.Sprintf("mask[%s]", mask)
+	}
+
+	r.Options.Mask = mask
+	return r
+}
+
+func (r Network_SecurityGroup_Service) CreateObject(templateObject *Network_SecurityGroup) (resp Network_SecurityGroup, err error) {
+	params := []interface{}{
+		templateObject,
+	}
+	err = r.Session.DoRequest("SoftLayer_Network_SecurityGroup", "createObject", params, &r.Options, &resp)
+	return
+}
+
+func (r Network_SecurityGroup_Service) EditObject(templateObject *Network_SecurityGroup) (resp bool, err error) {
+	params := []interface{}{
+		templateObject,
+	}
+	err = r.Session.DoRequest("SoftLayer_Network_SecurityGroup", "editObject", params, &r.Options, &resp)
+	return
+}
+
+func (r Network_SecurityGroup_Service) DeleteObject() (resp bool, err error) {
+	err = r.Session.DoRequest("SoftLayer_Network_SecurityGroup", "deleteObject", nil, &r.Options, &resp)
+	return
+}
+
+func (r Network_SecurityGroup_Service) GetObject() (resp Network_SecurityGroup, err error) {
+	err = r.Session.DoRequest("SoftLayer_Network_SecurityGroup", "getObject", nil, &r.Options, &resp)
+	return
+}
+
+func (r Network_SecurityGroup_Service) GetRules() (resp []Network_SecurityGroup_Rule, err error) {
+	err = r.Session.DoRequest("SoftLayer_Network_SecurityGroup", "getRules", nil, &r.Options, &resp)
+	return
+}
+
+func (r Network_SecurityGroup_Service) AddRules(ruleTemplates []Network_SecurityGroup_Rule) (resp interface{}, err error) {
+	params := []interface{}{
+		ruleTemplates,
+	}
+	err = r.Session.DoRequest("SoftLayer_Network_SecurityGroup", "addRules", params, &r.Options, &resp)
+	return
+}
+
+func (r Network_SecurityGroup_Service) EditRules(ruleTemplates []Network_SecurityGroup_Rule) (resp interface{}, err error) {
+	params := []interface{}{
+		ruleTemplates,
+	}
+	err = r.Session.DoRequest("SoftLayer_Network_SecurityGroup", "editRules", params, &r.Options, &resp)
+	return
+}
+
+func (r Network_SecurityGroup_Service) RemoveRules(ruleIds []int) (resp interface{}, err error) {
+	params := []interface{}{
+		ruleIds,
+	}
+	err = r.Session.DoRequest("SoftLayer_Network_SecurityGroup", "removeRules", params, &r.Options, &resp)
+	return
+}
+
+func (r Network_SecurityGroup_Service) AttachNetworkComponents(networkComponentIds []int) (resp interface{}, err error) {
+	params := []interface{}{
+		networkComponentIds,
+	}
+	err = r.Session.DoRequest("SoftLayer_Network_SecurityGroup", "attachNetworkComponents", params, &r.Options, &resp)
+	return
+}
+
+func (r Network_SecurityGroup_Service) DetachNetworkComponents(networkComponentIds []int) (resp interface{}, err error) {
+	params := []interface{}{
+		networkComponentIds,
+	}
+	err = r.Session.DoRequest("SoftLayer_Network_SecurityGroup", "detachNetworkComponents", params, &r.Options, &resp)
+	return
+}
+
+// getNetworkComponentSecurityGroupIds returns the ids of the security groups attached to a virtual
+// guest network component.
+func getNetworkComponentSecurityGroupIds(sess *session.Session, networkComponentId int) ([]int, error) {
+	bindings := []Network_SecurityGroup_ComponentBinding{}
+	options := sl.Options{Id: &networkComponentId}
+
+	err := sess.DoRequest("SoftLayer_Virtual_Guest_Network_Component", "getSecurityGroupBindings",
+		nil, &options, &bindings)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(bindings))
+	for _, binding := range bindings {
+		ids = append(ids, *binding.SecurityGroupId)
+	}
+
+	return ids, nil
+}
+
+// updateNetworkComponentSecurityGroups attaches and detaches security groups so that the network
+// component ends up bound to exactly the given security groups.
+func updateNetworkComponentSecurityGroups(sess *session.Session, networkComponentId int, oldIds, newIds *schema.Set) error {
+	for _, id := range oldIds.Difference(newIds).List() {
+		log.Printf("[INFO] Detaching security group %d from network component %d", id.(int), networkComponentId)
+		_, err := GetNetworkSecurityGroupService(sess).Id(id.(int)).
+			DetachNetworkComponents([]int{networkComponentId})
+		if err != nil {
+			return fmt.Errorf("Error detaching security group %d: %s", id.(int), err)
+		}
+	}
+
+	for _, id := range newIds.Difference(oldIds).List() {
+		log.Printf("[INFO] Attaching security group %d to network component %d", id.(int), networkComponentId)
+		_, err := GetNetworkSecurityGroupService(sess).Id(id.(int)).
+			AttachNetworkComponents([]int{networkComponentId})
+		if err != nil {
+			return fmt.Errorf("Error attaching security group %d: %s", id.(int), err)
+		}
+	}
+
+	return nil
+}
+
+func resourceSoftLayerSecurityGroup() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceSoftLayerSecurityGroupCreate,
+		Read:     resourceSoftLayerSecurityGroupRead,
+		Update:   resourceSoftLayerSecurityGroupUpdate,
+		Delete:   resourceSoftLayerSecurityGroupDelete,
+		Exists:   resourceSoftLayerSecurityGroupExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerSecurityGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := GetNetworkSecurityGroupService(sess)
+
+	opts := Network_SecurityGroup{
+		Name: sl.String(d.Get("name").(string)),
+	}
+
+	if description, ok := d.GetOk("description"); ok {
+		opts.Description = sl.String(description.(string))
+	}
+
+	sg, err := service.CreateObject(&opts)
+	if err != nil {
+		return fmt.Errorf("Error creating security group: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(*sg.Id))
+	log.Printf("[INFO] Security group: %d", *sg.Id)
+
+	return resourceSoftLayerSecurityGroupRead(d, meta)
+}
+
+func resourceSoftLayerSecurityGroupRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := GetNetworkSecurityGroupService(sess)
+
+	sgId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	sg, err := service.Id(sgId).GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving security group: %s", err)
+	}
+
+	d.Set("name", sl.Get(sg.Name, ""))
+	d.Set("description", sl.Get(sg.Description, ""))
+
+	return nil
+}
+
+func resourceSoftLayerSecurityGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := GetNetworkSecurityGroupService(sess)
+
+	sgId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	opts := Network_SecurityGroup{
+		Name:        sl.String(d.Get("name").(string)),
+		Description: sl.String(d.Get("description").(string)),
+	}
+
+	_, err = service.Id(sgId).EditObject(&opts)
+	if err != nil {
+		return fmt.Errorf("Error updating security group: %s", err)
+	}
+
+	return resourceSoftLayerSecurityGroupRead(d, meta)
+}
+
+func resourceSoftLayerSecurityGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	service := GetNetworkSecurityGroupService(sess)
+
+	sgId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	// Security groups can't be deleted while they are attached to network components
+	sg, err := service.Id(sgId).Mask("id,networkComponentBindings[networkComponentId]").GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving security group: %s", err)
+	}
+
+	if len(sg.Bindings) > 0 {
+		componentIds := make([]int, 0, len(sg.Bindings))
+		for _, binding := range sg.Bindings {
+			componentIds = append(componentIds, *binding.NetworkComponentId)
+		}
+
+		_, err = service.Id(sgId).DetachNetworkComponents(componentIds)
+		if err != nil {
+			return fmt.Errorf("Error detaching security group: %s", err)
+		}
+	}
+
+	log.Printf("[INFO] Deleting security group: %d", sgId)
+	_, err = service.Id(sgId).DeleteObject()
+	if err != nil {
+		return fmt.Errorf("Error deleting security group: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerSecurityGroupExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+	service := GetNetworkSecurityGroupService(sess)
+
+	sgId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	result, err := service.Id(sgId).Mask("id").GetObject()
+	return result.Id != nil && err == nil && *result.Id == sgId, nil
+}
@@ -0,0 +1,284 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func resourceSoftLayerSecurityGroupRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSoftLayerSecurityGroupRuleCreate,
+		Read:   resourceSoftLayerSecurityGroupRuleRead,
+		Update: resourceSoftLayerSecurityGroupRuleUpdate,
+		Delete: resourceSoftLayerSecurityGroupRuleDelete,
+		Exists: resourceSoftLayerSecurityGroupRuleExists,
+
+		Schema: map[string]*schema.Schema{
+			"security_group_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"direction": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					direction := v.(string)
+					if direction != "ingress" && direction != "egress" {
+						errors = append(errors, fmt.Errorf(
+							"Invalid direction: direction should be either 'ingress' or 'egress'"))
+					}
+					return
+				},
+			},
+
+			"ether_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "IPv4",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					etherType := v.(string)
+					if etherType != "IPv4" && etherType != "IPv6" {
+						errors = append(errors, fmt.Errorf(
+							"Invalid ether_type: ether_type should be either 'IPv4' or 'IPv6'"))
+					}
+					return
+				},
+			},
+
+			"port_range_min": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"port_range_max": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+
+			"protocol": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					protocol := v.(string)
+					if protocol != "tcp" && protocol != "udp" && protocol != "icmp" {
+						errors = append(errors, fmt.Errorf(
+							"Invalid protocol: protocol should be 'tcp', 'udp' or 'icmp'"))
+					}
+					return
+				},
+			},
+
+			"remote_group_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"remote_ip"},
+			},
+
+			"remote_ip": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"remote_group_id"},
+			},
+		},
+	}
+}
+
+func getSecurityGroupRuleFromResourceData(d *schema.ResourceData) Network_SecurityGroup_Rule {
+	rule := Network_SecurityGroup_Rule{
+		Direction: sl.String(d.Get("direction").(string)),
+		Ethertype: sl.String(d.Get("ether_type").(string)),
+	}
+
+	if portRangeMin, ok := d.GetOk("port_range_min"); ok {
+		rule.PortRangeMin = sl.Int(portRangeMin.(int))
+	}
+
+	if portRangeMax, ok := d.GetOk("port_range_max"); ok {
+		rule.PortRangeMax = sl.Int(portRangeMax.(int))
+	}
+
+	if protocol, ok := d.GetOk("protocol"); ok {
+		rule.Protocol = sl.String(protocol.(string))
+	}
+
+	if remoteGroupId, ok := d.GetOk("remote_group_id"); ok {
+		rule.RemoteGroupId = sl.Int(remoteGroupId.(int))
+	}
+
+	if remoteIp, ok := d.GetOk("remote_ip"); ok {
+		rule.RemoteIp = sl.String(remoteIp.(string))
+	}
+
+	return rule
+}
+
+func findSecurityGroupRule(sess *session.Session, sgId int, ruleId int) (Network_SecurityGroup_Rule, error) {
+	rules, err := GetNetworkSecurityGroupService(sess).Id(sgId).GetRules()
+	if err != nil {
+		return Network_SecurityGroup_Rule{}, err
+	}
+
+	for _, rule := range rules {
+		if rule.Id != nil && *rule.Id == ruleId {
+			return rule, nil
+		}
+	}
+
+	return Network_SecurityGroup_Rule{},
+		fmt.Errorf("Security group rule %d not found in security group %d", ruleId, sgId)
+}
+
+// Rules of a security group are created one at a time, as a new rule is found by comparing the rules of the
+// group before and after it is added.
+var (
+	securityGroupRuleMutexes      = map[int]*sync.Mutex{}
+	securityGroupRuleMutexesMutex sync.Mutex
+)
+
+func getSecurityGroupRuleMutex(sgId int) *sync.Mutex {
+	securityGroupRuleMutexesMutex.Lock()
+	defer securityGroupRuleMutexesMutex.Unlock()
+
+	if _, ok := securityGroupRuleMutexes[sgId]; !ok {
+		securityGroupRuleMutexes[sgId] = &sync.Mutex{}
+	}
+
+	return securityGroupRuleMutexes[sgId]
+}
+
+// matchSecurityGroupRule returns true if a rule of a security group has the settings of the given rule.
+func matchSecurityGroupRule(rule Network_SecurityGroup_Rule, template Network_SecurityGroup_Rule) bool {
+	return sl.Get(rule.Direction, "") == sl.Get(template.Direction, "") &&
+		sl.Get(rule.Ethertype, "IPv4") == sl.Get(template.Ethertype, "IPv4") &&
+		sl.Get(rule.PortRangeMin, 0) == sl.Get(template.PortRangeMin, 0) &&
+		sl.Get(rule.PortRangeMax, 0) == sl.Get(template.PortRangeMax, 0) &&
+		sl.Get(rule.Protocol, "") == sl.Get(template.Protocol, "") &&
+		sl.Get(rule.RemoteGroupId, 0) == sl.Get(template.RemoteGroupId, 0) &&
+		sl.Get(rule.RemoteIp, "") == sl.Get(template.RemoteIp, "")
+}
+
+func resourceSoftLayerSecurityGroupRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	sgId := d.Get("security_group_id").(int)
+	template := getSecurityGroupRuleFromResourceData(d)
+
+	mutex := getSecurityGroupRuleMutex(sgId)
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	before, err := GetNetworkSecurityGroupService(sess).Id(sgId).GetRules()
+	if err != nil {
+		return fmt.Errorf("Error retrieving security group rules: %s", err)
+	}
+
+	_, err = GetNetworkSecurityGroupService(sess).Id(sgId).
+		AddRules([]Network_SecurityGroup_Rule{template})
+	if err != nil {
+		return fmt.Errorf("Error creating security group rule: %s", err)
+	}
+
+	// addRules doesn't return the new rule, so it is the rule with the same settings which wasn't in the
+	// group before.
+	after, err := GetNetworkSecurityGroupService(sess).Id(sgId).GetRules()
+	if err != nil {
+		return fmt.Errorf("Error retrieving security group rules: %s", err)
+	}
+
+	existing := make([]int, 0, len(before))
+	for _, rule := range before {
+		existing = append(existing, *rule.Id)
+	}
+
+	for _, rule := range after {
+		if !contains(existing, *rule.Id) && matchSecurityGroupRule(rule, template) {
+			d.SetId(strconv.Itoa(*rule.Id))
+			log.Printf("[INFO] Security group rule: %d", *rule.Id)
+			return resourceSoftLayerSecurityGroupRuleRead(d, meta)
+		}
+	}
+
+	return fmt.Errorf("Error creating security group rule: rule not found in security group %d", sgId)
+}
+
+func resourceSoftLayerSecurityGroupRuleRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	ruleId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	rule, err := findSecurityGroupRule(sess, d.Get("security_group_id").(int), ruleId)
+	if err != nil {
+		return fmt.Errorf("Error retrieving security group rule: %s", err)
+	}
+
+	d.Set("direction", sl.Get(rule.Direction, ""))
+	d.Set("ether_type", sl.Get(rule.Ethertype, "IPv4"))
+	d.Set("port_range_min", sl.Get(rule.PortRangeMin, 0))
+	d.Set("port_range_max", sl.Get(rule.PortRangeMax, 0))
+	d.Set("protocol", sl.Get(rule.Protocol, ""))
+	d.Set("remote_group_id", sl.Get(rule.RemoteGroupId, 0))
+	d.Set("remote_ip", sl.Get(rule.RemoteIp, ""))
+
+	return nil
+}
+
+func resourceSoftLayerSecurityGroupRuleUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	ruleId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	rule := getSecurityGroupRuleFromResourceData(d)
+	rule.Id = sl.Int(ruleId)
+
+	_, err = GetNetworkSecurityGroupService(sess).Id(d.Get("security_group_id").(int)).
+		EditRules([]Network_SecurityGroup_Rule{rule})
+	if err != nil {
+		return fmt.Errorf("Error updating security group rule: %s", err)
+	}
+
+	return resourceSoftLayerSecurityGroupRuleRead(d, meta)
+}
+
+func resourceSoftLayerSecurityGroupRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	ruleId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	log.Printf("[INFO] Deleting security group rule: %d", ruleId)
+	_, err = GetNetworkSecurityGroupService(sess).Id(d.Get("security_group_id").(int)).
+		RemoveRules([]int{ruleId})
+	if err != nil {
+		return fmt.Errorf("Error deleting security group rule: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerSecurityGroupRuleExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+
+	ruleId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	_, err = findSecurityGroupRule(sess, d.Get("security_group_id").(int), ruleId)
+	return err == nil, nil
+}
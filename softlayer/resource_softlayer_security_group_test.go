@@ -0,0 +1,196 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func TestAccSoftLayerSecurityGroup_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerSecurityGroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerSecurityGroupConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerSecurityGroupExists("softlayer_security_group.testacc_sg"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group.testacc_sg", "name", "testacc_sg"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group.testacc_sg", "description", "first description"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group_rule.testacc_ssh", "direction", "ingress"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group_rule.testacc_ssh", "ether_type", "IPv4"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group_rule.testacc_ssh", "port_range_min", "22"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group_rule.testacc_ssh", "port_range_max", "22"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group_rule.testacc_ssh", "protocol", "tcp"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group_rule.testacc_ssh", "remote_ip", "10.0.0.0/8"),
+					resource.TestCheckResourceAttr(
+						"softlayer_virtual_guest.testacc_sg_guest", "security_group_ids.#", "1"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerSecurityGroupConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSoftLayerSecurityGroupExists("softlayer_security_group.testacc_sg"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group.testacc_sg", "description", "changed description"),
+					resource.TestCheckResourceAttr(
+						"softlayer_security_group_rule.testacc_ssh", "port_range_max", "23"),
+					resource.TestCheckResourceAttr(
+						"softlayer_virtual_guest.testacc_sg_guest", "security_group_ids.#", "0"),
+					resource.TestCheckResourceAttr(
+						"softlayer_virtual_guest.testacc_sg_guest", "private_security_group_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestMatchSecurityGroupRule(t *testing.T) {
+	template := Network_SecurityGroup_Rule{
+		Direction:    sl.String("ingress"),
+		Ethertype:    sl.String("IPv4"),
+		PortRangeMin: sl.Int(22),
+		PortRangeMax: sl.Int(22),
+		Protocol:     sl.String("tcp"),
+	}
+
+	rule := template
+	rule.Id = sl.Int(1)
+	if !matchSecurityGroupRule(rule, template) {
+		t.Error("Expected the rule to match")
+	}
+
+	rule.PortRangeMax = sl.Int(23)
+	if matchSecurityGroupRule(rule, template) {
+		t.Error("Expected a rule with another port range not to match")
+	}
+
+	rule = template
+	rule.RemoteIp = sl.String("10.0.0.0/24")
+	if matchSecurityGroupRule(rule, template) {
+		t.Error("Expected a rule with a remote IP not to match")
+	}
+}
+
+func testAccCheckSoftLayerSecurityGroupDestroy(s *terraform.State) error {
+	service := GetNetworkSecurityGroupService(testAccProvider.Meta().(*session.Session))
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_security_group" {
+			continue
+		}
+
+		sgId, _ := strconv.Atoi(rs.Primary.ID)
+
+		// Try to find the security group
+		_, err := service.Id(sgId).GetObject()
+
+		if err == nil {
+			return fmt.Errorf("Security group still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckSoftLayerSecurityGroupExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		sgId, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := GetNetworkSecurityGroupService(testAccProvider.Meta().(*session.Session))
+		foundSg, err := service.Id(sgId).GetObject()
+
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(*foundSg.Id) != rs.Primary.ID {
+			return fmt.Errorf("Record not found")
+		}
+
+		return nil
+	}
+}
+
+const testAccCheckSoftLayerSecurityGroupConfig_basic = `
+resource "softlayer_security_group" "testacc_sg" {
+    name = "testacc_sg"
+    description = "first description"
+}
+
+resource "softlayer_security_group_rule" "testacc_ssh" {
+    security_group_id = "${softlayer_security_group.testacc_sg.id}"
+    direction = "ingress"
+    port_range_min = 22
+    port_range_max = 22
+    protocol = "tcp"
+    remote_ip = "10.0.0.0/8"
+}
+
+resource "softlayer_virtual_guest" "testacc_sg_guest" {
+    name = "terraform-sg-test"
+    domain = "bar.example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "ams01"
+    network_speed = 10
+    hourly_billing = true
+    cpu = 1
+    ram = 1024
+    local_disk = false
+    security_group_ids = ["${softlayer_security_group.testacc_sg.id}"]
+}
+`
+
+const testAccCheckSoftLayerSecurityGroupConfig_updated = `
+resource "softlayer_security_group" "testacc_sg" {
+    name = "testacc_sg"
+    description = "changed description"
+}
+
+resource "softlayer_security_group_rule" "testacc_ssh" {
+    security_group_id = "${softlayer_security_group.testacc_sg.id}"
+    direction = "ingress"
+    port_range_min = 22
+    port_range_max = 23
+    protocol = "tcp"
+    remote_ip = "10.0.0.0/8"
+}
+
+resource "softlayer_virtual_guest" "testacc_sg_guest" {
+    name = "terraform-sg-test"
+    domain = "bar.example.com"
+    os_reference_code = "DEBIAN_7_64"
+    datacenter = "ams01"
+    network_speed = 10
+    hourly_billing = true
+    cpu = 1
+    ram = 1024
+    local_disk = false
+    private_security_group_ids = ["${softlayer_security_group.testacc_sg.id}"]
+}
+`
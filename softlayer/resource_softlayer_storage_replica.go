@@ -0,0 +1,292 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/helpers/location"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	StorageReplicaOriginMask = "id,capacityGb,snapshotCapacityGb,storageTierLevel,storageType[keyName]," +
+		"osType[keyName],schedules[id,type[keyname]]"
+)
+
+func resourceSoftLayerStorageReplica() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSoftLayerStorageReplicaCreate,
+		Read:   resourceSoftLayerStorageReplicaRead,
+		Update: resourceSoftLayerStorageReplicaUpdate,
+		Delete: resourceSoftLayerStorageReplicaDelete,
+		Exists: resourceSoftLayerStorageReplicaExists,
+
+		Schema: map[string]*schema.Schema{
+			"storage_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"datacenter": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"schedule_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					scheduleType := v.(string)
+					if scheduleType != "HOURLY" && scheduleType != "DAILY" && scheduleType != "WEEKLY" {
+						errors = append(errors, fmt.Errorf(
+							"Invalid schedule_type: schedule_type should be 'HOURLY', 'DAILY' or 'WEEKLY'"))
+					}
+					return
+				},
+			},
+
+			"failover": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"volume_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"target_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"replication_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerStorageReplicaCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	originId := d.Get("storage_id").(int)
+	scheduleType := d.Get("schedule_type").(string)
+	datacenter := d.Get("datacenter").(string)
+
+	origin, err := services.GetNetworkStorageService(sess).
+		Id(originId).
+		Mask(StorageReplicaOriginMask).
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving storage %d: %s", originId, err)
+	}
+
+	// The replica is a copy of the origin volume. It has the same size, tier and snapshot space.
+	storageTypeKeyName := ""
+	if origin.StorageType != nil {
+		storageTypeKeyName = sl.Get(origin.StorageType.KeyName, "").(string)
+	}
+
+	if !strings.HasPrefix(storageTypeKeyName, "ENDURANCE") {
+		return fmt.Errorf("Error creating storage replica: only %s storage can be replicated", EnduranceType)
+	}
+
+	storageProtocol := "nfs"
+	if strings.Contains(storageTypeKeyName, "BLOCK") {
+		storageProtocol = "iscsi"
+	}
+
+	var iops float64
+	for tierIops, tier := range enduranceTiers {
+		if tier.keyName == sl.Get(origin.StorageTierLevel, "").(string) {
+			iops = tierIops
+		}
+	}
+
+	snapshotCapacity, err := strconv.Atoi(sl.Get(origin.SnapshotCapacityGb, "0").(string))
+	if err != nil || snapshotCapacity == 0 {
+		return fmt.Errorf("Error creating storage replica: storage %d has no snapshot space", originId)
+	}
+
+	// Replication follows one of the snapshot schedules of the origin volume.
+	var scheduleId *int
+	for _, schedule := range origin.Schedules {
+		if schedule.Type != nil && sl.Get(schedule.Type.Keyname, "").(string) == "SNAPSHOT_"+scheduleType {
+			scheduleId = schedule.Id
+		}
+	}
+
+	if scheduleId == nil {
+		return fmt.Errorf(
+			"Error creating storage replica: storage %d has no %s snapshot schedule", originId, scheduleType)
+	}
+
+	dc, err := location.GetDatacenterByName(sess, datacenter, "id,priceGroups[id]")
+	if err != nil {
+		return fmt.Errorf("Error creating storage replica: %s", err)
+	}
+
+	if dc.Id == nil {
+		return fmt.Errorf("Error creating storage replica: no datacenter found with name %s", datacenter)
+	}
+
+	pkgId, prices, err := getStorageOrderPrices(sess, storageOrder{
+		storageType:      EnduranceType,
+		storageProtocol:  storageProtocol,
+		capacity:         sl.Get(origin.CapacityGb, 0).(int),
+		iops:             iops,
+		snapshotCapacity: snapshotCapacity,
+		replication:      true,
+	}, dc)
+	if err != nil {
+		return fmt.Errorf("Error creating storage replica: %s", err)
+	}
+
+	productOrderContainer := datatypes.Container_Product_Order_Network_Storage_Enterprise{
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: sl.Int(pkgId),
+			Location:  sl.String(strconv.Itoa(*dc.Id)),
+			Prices:    prices,
+			Quantity:  sl.Int(1),
+		},
+		OriginVolumeId:         sl.Int(originId),
+		OriginVolumeScheduleId: scheduleId,
+		OsFormatType:           origin.OsType,
+	}
+
+	log.Printf("[INFO] Creating replica of storage %d", originId)
+
+	receipt, err := services.GetProductOrderService(sess).
+		PlaceOrder(&productOrderContainer, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of storage replica: %s", err)
+	}
+
+	replica, err := findStorageByOrderId(sess, *receipt.OrderId)
+	if err != nil {
+		return fmt.Errorf("Error finding created storage replica: %s", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", *replica.Id))
+	log.Printf("[INFO] Storage replica ID: %s", d.Id())
+
+	if d.Get("failover").(bool) {
+		err = failoverStorageReplica(d, sess)
+		if err != nil {
+			return fmt.Errorf("Error updating storage replica: %s", err)
+		}
+	}
+
+	return resourceSoftLayerStorageReplicaRead(d, meta)
+}
+
+func resourceSoftLayerStorageReplicaRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	replicaId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	replica, err := services.GetNetworkStorageService(sess).
+		Id(replicaId).
+		Mask("id,username,serviceResourceBackendIpAddress,serviceResource[datacenter[name]]").
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving storage replica: %s", err)
+	}
+
+	d.Set("volume_name", sl.Get(replica.Username, ""))
+	d.Set("target_address", sl.Get(replica.ServiceResourceBackendIpAddress, ""))
+
+	if replica.ServiceResource != nil && replica.ServiceResource.Datacenter != nil {
+		d.Set("datacenter", sl.Get(replica.ServiceResource.Datacenter.Name, ""))
+	}
+
+	// The replication status is kept by the origin volume.
+	status, err := services.GetNetworkStorageService(sess).
+		Id(d.Get("storage_id").(int)).
+		GetReplicationStatus()
+	if err != nil {
+		return fmt.Errorf("Error retrieving replication status: %s", err)
+	}
+
+	d.Set("replication_status", status)
+
+	return nil
+}
+
+func resourceSoftLayerStorageReplicaUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	if d.HasChange("failover") {
+		err := failoverStorageReplica(d, sess)
+		if err != nil {
+			return fmt.Errorf("Error updating storage replica: %s", err)
+		}
+	}
+
+	return resourceSoftLayerStorageReplicaRead(d, meta)
+}
+
+func resourceSoftLayerStorageReplicaDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	replicaId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	err = cancelStorage(sess, replicaId)
+	if err != nil {
+		return fmt.Errorf("Error deleting storage replica: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerStorageReplicaExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+
+	replicaId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	result, err := services.GetNetworkStorageService(sess).Id(replicaId).Mask("id").GetObject()
+	return result.Id != nil && err == nil && *result.Id == replicaId, nil
+}
+
+// failoverStorageReplica fails the origin volume over to the replica when failover is set, and fails
+// it back from the replica otherwise.
+func failoverStorageReplica(d *schema.ResourceData, sess *session.Session) error {
+	replicaId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	service := services.GetNetworkStorageService(sess).Id(d.Get("storage_id").(int))
+
+	if d.Get("failover").(bool) {
+		log.Printf("[INFO] Failing over storage %d to replica %d", d.Get("storage_id").(int), replicaId)
+		_, err = service.FailoverToReplicant(sl.Int(replicaId))
+	} else {
+		log.Printf("[INFO] Failing back storage %d from replica %d", d.Get("storage_id").(int), replicaId)
+		_, err = service.FailbackFromReplicant()
+	}
+
+	return err
+}
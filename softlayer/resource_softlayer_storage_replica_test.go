@@ -0,0 +1,81 @@
+package softlayer
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccSoftLayerStorageReplica_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerBlockStorageDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerStorageReplicaConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_replica.replica", "datacenter", "dal09"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_replica.replica", "failover", "false"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_storage_replica.replica", "volume_name"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_storage_replica.replica", "target_address"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerStorageReplicaConfig_failover,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_replica.replica", "failover", "true"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerStorageReplicaConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_replica.replica", "failover", "false"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckSoftLayerStorageReplicaConfig_origin = `
+resource "softlayer_block_storage" "bs_origin" {
+    type = "Endurance"
+    datacenter = "dal06"
+    capacity = 20
+    iops = 2
+    snapshot_capacity = 10
+    os_type = "LINUX"
+}
+
+resource "softlayer_storage_snapshot_schedule" "daily" {
+    storage_id = "${softlayer_block_storage.bs_origin.id}"
+    type = "DAILY"
+    retention_count = 2
+    hour = 1
+}
+`
+
+const testAccCheckSoftLayerStorageReplicaConfig_basic = testAccCheckSoftLayerStorageReplicaConfig_origin + `
+resource "softlayer_storage_replica" "replica" {
+    storage_id = "${softlayer_storage_snapshot_schedule.daily.storage_id}"
+    datacenter = "dal09"
+    schedule_type = "DAILY"
+}
+`
+
+const testAccCheckSoftLayerStorageReplicaConfig_failover = testAccCheckSoftLayerStorageReplicaConfig_origin + `
+resource "softlayer_storage_replica" "replica" {
+    storage_id = "${softlayer_storage_snapshot_schedule.daily.storage_id}"
+    datacenter = "dal09"
+    schedule_type = "DAILY"
+    failover = true
+}
+`
@@ -0,0 +1,144 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func resourceSoftLayerStorageSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSoftLayerStorageSnapshotCreate,
+		Read:   resourceSoftLayerStorageSnapshotRead,
+		Update: resourceSoftLayerStorageSnapshotUpdate,
+		Delete: resourceSoftLayerStorageSnapshotDelete,
+		Exists: resourceSoftLayerStorageSnapshotExists,
+
+		Schema: map[string]*schema.Schema{
+			"storage_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"notes": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"create_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"size_bytes": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSoftLayerStorageSnapshotCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+	storageId := d.Get("storage_id").(int)
+
+	log.Printf("[INFO] Creating snapshot of storage: %d", storageId)
+	snapshot, err := services.GetNetworkStorageService(sess).
+		Id(storageId).
+		CreateSnapshot(sl.String(d.Get("notes").(string)))
+	if err != nil {
+		return fmt.Errorf("Error creating snapshot: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(*snapshot.Id))
+	log.Printf("[INFO] Snapshot ID: %s", d.Id())
+
+	return resourceSoftLayerStorageSnapshotRead(d, meta)
+}
+
+func resourceSoftLayerStorageSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	snapshotId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	snapshot, err := services.GetNetworkStorageService(sess).
+		Id(snapshotId).
+		Mask("id,notes,createDate,snapshotSizeBytes,parentVolume[id]").
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving snapshot: %s", err)
+	}
+
+	if snapshot.ParentVolume != nil && snapshot.ParentVolume.Id != nil {
+		d.Set("storage_id", *snapshot.ParentVolume.Id)
+	}
+
+	d.Set("notes", sl.Get(snapshot.Notes, ""))
+	d.Set("size_bytes", sl.Get(snapshot.SnapshotSizeBytes, ""))
+
+	if snapshot.CreateDate != nil {
+		d.Set("create_date", snapshot.CreateDate.String())
+	}
+
+	return nil
+}
+
+func resourceSoftLayerStorageSnapshotUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	snapshotId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	if d.HasChange("notes") {
+		_, err = services.GetNetworkStorageService(sess).
+			Id(snapshotId).
+			EditObject(&datatypes.Network_Storage{Notes: sl.String(d.Get("notes").(string))})
+		if err != nil {
+			return fmt.Errorf("Error updating snapshot: %s", err)
+		}
+	}
+
+	return resourceSoftLayerStorageSnapshotRead(d, meta)
+}
+
+func resourceSoftLayerStorageSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	snapshotId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	log.Printf("[INFO] Deleting snapshot: %d", snapshotId)
+	_, err = services.GetNetworkStorageService(sess).Id(snapshotId).DeleteObject()
+	if err != nil {
+		return fmt.Errorf("Error deleting snapshot: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerStorageSnapshotExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+
+	snapshotId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	result, err := services.GetNetworkStorageService(sess).Id(snapshotId).Mask("id").GetObject()
+	return result.Id != nil && err == nil && *result.Id == snapshotId, nil
+}
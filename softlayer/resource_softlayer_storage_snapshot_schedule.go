@@ -0,0 +1,270 @@
+package softlayer
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	StorageScheduleMask = "id,active,minute,hour,dayOfWeek,retentionCount,volumeId,type[keyname]"
+)
+
+// Weekly schedules return the day of the week as a number starting with Sunday.
+var daysOfWeek = []string{"SUNDAY", "MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY"}
+
+func resourceSoftLayerStorageSnapshotSchedule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSoftLayerStorageSnapshotScheduleCreate,
+		Read:   resourceSoftLayerStorageSnapshotScheduleRead,
+		Update: resourceSoftLayerStorageSnapshotScheduleUpdate,
+		Delete: resourceSoftLayerStorageSnapshotScheduleDelete,
+		Exists: resourceSoftLayerStorageSnapshotScheduleExists,
+
+		Schema: map[string]*schema.Schema{
+			"storage_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					scheduleType := v.(string)
+					if scheduleType != "HOURLY" && scheduleType != "DAILY" && scheduleType != "WEEKLY" {
+						errors = append(errors, fmt.Errorf(
+							"Invalid type: type should be 'HOURLY', 'DAILY' or 'WEEKLY'"))
+					}
+					return
+				},
+			},
+
+			"retention_count": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+
+			"minute": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					minute := v.(int)
+					if minute < 0 || minute > 59 {
+						errors = append(errors, fmt.Errorf("Invalid minute: minute should be between 0 and 59"))
+					}
+					return
+				},
+			},
+
+			"hour": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					hour := v.(int)
+					if hour < 0 || hour > 23 {
+						errors = append(errors, fmt.Errorf("Invalid hour: hour should be between 0 and 23"))
+					}
+					return
+				},
+			},
+
+			"day_of_week": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "SUNDAY",
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					day := v.(string)
+					for _, d := range daysOfWeek {
+						if day == d {
+							return
+						}
+					}
+					errors = append(errors, fmt.Errorf(
+						"Invalid day_of_week: day_of_week should be one of %s", strings.Join(daysOfWeek, ", ")))
+					return
+				},
+			},
+
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+// enableStorageSnapshotSchedule creates or updates the snapshot schedule of the given type on the
+// volume. A volume has at most one schedule of each type, so the schedule is found by its type.
+func enableStorageSnapshotSchedule(d *schema.ResourceData, sess *session.Session) (int, error) {
+	storageId := d.Get("storage_id").(int)
+	scheduleType := d.Get("type").(string)
+
+	service := services.GetNetworkStorageService(sess).Id(storageId)
+
+	_, err := service.EnableSnapshots(
+		sl.String(scheduleType),
+		sl.Int(d.Get("retention_count").(int)),
+		sl.Int(d.Get("minute").(int)),
+		sl.Int(d.Get("hour").(int)),
+		sl.String(d.Get("day_of_week").(string)),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	schedules, err := service.Mask("id,type[keyname]").GetSchedules()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, schedule := range schedules {
+		if schedule.Type != nil && sl.Get(schedule.Type.Keyname, "").(string) == "SNAPSHOT_"+scheduleType {
+			return *schedule.Id, nil
+		}
+	}
+
+	return 0, fmt.Errorf("No %s snapshot schedule found on storage %d", scheduleType, storageId)
+}
+
+// setStorageSnapshotScheduleActive activates or deactivates a snapshot schedule.
+func setStorageSnapshotScheduleActive(sess *session.Session, scheduleId int, enabled bool) error {
+	active := 0
+	if enabled {
+		active = 1
+	}
+
+	log.Printf("[INFO] Setting snapshot schedule %d active: %t", scheduleId, enabled)
+	_, err := services.GetNetworkStorageScheduleService(sess).
+		Id(scheduleId).
+		EditObject(&datatypes.Network_Storage_Schedule{Active: sl.Int(active)})
+
+	return err
+}
+
+func resourceSoftLayerStorageSnapshotScheduleCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	scheduleId, err := enableStorageSnapshotSchedule(d, sess)
+	if err != nil {
+		return fmt.Errorf("Error creating snapshot schedule: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(scheduleId))
+	log.Printf("[INFO] Snapshot schedule ID: %s", d.Id())
+
+	// Enabled snapshots create an active schedule.
+	if !d.Get("enabled").(bool) {
+		err = setStorageSnapshotScheduleActive(sess, scheduleId, false)
+		if err != nil {
+			return fmt.Errorf("Error disabling snapshot schedule: %s", err)
+		}
+	}
+
+	return resourceSoftLayerStorageSnapshotScheduleRead(d, meta)
+}
+
+func resourceSoftLayerStorageSnapshotScheduleRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	scheduleId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	schedule, err := services.GetNetworkStorageScheduleService(sess).
+		Id(scheduleId).
+		Mask(StorageScheduleMask).
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving snapshot schedule: %s", err)
+	}
+
+	if schedule.VolumeId != nil {
+		d.Set("storage_id", *schedule.VolumeId)
+	}
+
+	if schedule.Type != nil && schedule.Type.Keyname != nil {
+		d.Set("type", strings.TrimPrefix(*schedule.Type.Keyname, "SNAPSHOT_"))
+	}
+
+	d.Set("enabled", sl.Get(schedule.Active, 0).(int) == 1)
+
+	if retentionCount, err := strconv.Atoi(sl.Get(schedule.RetentionCount, "").(string)); err == nil {
+		d.Set("retention_count", retentionCount)
+	}
+
+	// Fields which don't apply to the schedule type are returned as "*" and are left as configured.
+	if minute, err := strconv.Atoi(sl.Get(schedule.Minute, "").(string)); err == nil {
+		d.Set("minute", minute)
+	}
+
+	if hour, err := strconv.Atoi(sl.Get(schedule.Hour, "").(string)); err == nil {
+		d.Set("hour", hour)
+	}
+
+	if day, err := strconv.Atoi(sl.Get(schedule.DayOfWeek, "").(string)); err == nil && day >= 0 && day < len(daysOfWeek) {
+		d.Set("day_of_week", daysOfWeek[day])
+	}
+
+	return nil
+}
+
+func resourceSoftLayerStorageSnapshotScheduleUpdate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	// Enabling snapshots again replaces the settings of the existing schedule.
+	scheduleId, err := enableStorageSnapshotSchedule(d, sess)
+	if err != nil {
+		return fmt.Errorf("Error updating snapshot schedule: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(scheduleId))
+
+	// Enabling snapshots may activate the schedule again, so the state of the schedule is always set.
+	err = setStorageSnapshotScheduleActive(sess, scheduleId, d.Get("enabled").(bool))
+	if err != nil {
+		return fmt.Errorf("Error updating snapshot schedule: %s", err)
+	}
+
+	return resourceSoftLayerStorageSnapshotScheduleRead(d, meta)
+}
+
+func resourceSoftLayerStorageSnapshotScheduleDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(*session.Session)
+
+	log.Printf("[INFO] Disabling snapshot schedule: %s", d.Id())
+	_, err := services.GetNetworkStorageService(sess).
+		Id(d.Get("storage_id").(int)).
+		DisableSnapshots(sl.String(d.Get("type").(string)))
+	if err != nil {
+		return fmt.Errorf("Error deleting snapshot schedule: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceSoftLayerStorageSnapshotScheduleExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(*session.Session)
+
+	scheduleId, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	result, err := services.GetNetworkStorageScheduleService(sess).Id(scheduleId).Mask("id").GetObject()
+	return result.Id != nil && err == nil && *result.Id == scheduleId, nil
+}
@@ -0,0 +1,129 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerStorageSnapshotSchedule_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerStorageSnapshotScheduleDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerStorageSnapshotScheduleConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot_schedule.hourly", "type", "HOURLY"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot_schedule.hourly", "retention_count", "5"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot_schedule.hourly", "minute", "30"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot_schedule.hourly", "enabled", "true"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot_schedule.weekly", "type", "WEEKLY"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot_schedule.weekly", "hour", "2"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot_schedule.weekly", "day_of_week", "SATURDAY"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerStorageSnapshotScheduleConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot_schedule.hourly", "retention_count", "10"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot_schedule.hourly", "minute", "15"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot_schedule.weekly", "day_of_week", "SUNDAY"),
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot_schedule.weekly", "enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerStorageSnapshotScheduleDestroy(s *terraform.State) error {
+	service := services.GetNetworkStorageScheduleService(testAccProvider.Meta().(*session.Session))
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_storage_snapshot_schedule" {
+			continue
+		}
+
+		scheduleId, _ := strconv.Atoi(rs.Primary.ID)
+
+		// Disabled schedules are kept by SoftLayer, but are no longer active.
+		schedule, err := service.Id(scheduleId).GetObject()
+
+		if err == nil && schedule.Active != nil && *schedule.Active == 1 {
+			return fmt.Errorf("Snapshot schedule %d is still active", scheduleId)
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckSoftLayerStorageSnapshotScheduleConfig_basic = `
+resource "softlayer_block_storage" "bs_snapshot" {
+    type = "Endurance"
+    datacenter = "dal06"
+    capacity = 20
+    iops = 2
+    snapshot_capacity = 10
+    os_type = "LINUX"
+}
+
+resource "softlayer_storage_snapshot_schedule" "hourly" {
+    storage_id = "${softlayer_block_storage.bs_snapshot.id}"
+    type = "HOURLY"
+    retention_count = 5
+    minute = 30
+}
+
+resource "softlayer_storage_snapshot_schedule" "weekly" {
+    storage_id = "${softlayer_block_storage.bs_snapshot.id}"
+    type = "WEEKLY"
+    retention_count = 2
+    hour = 2
+    day_of_week = "SATURDAY"
+}
+`
+
+const testAccCheckSoftLayerStorageSnapshotScheduleConfig_updated = `
+resource "softlayer_block_storage" "bs_snapshot" {
+    type = "Endurance"
+    datacenter = "dal06"
+    capacity = 20
+    iops = 2
+    snapshot_capacity = 10
+    os_type = "LINUX"
+}
+
+resource "softlayer_storage_snapshot_schedule" "hourly" {
+    storage_id = "${softlayer_block_storage.bs_snapshot.id}"
+    type = "HOURLY"
+    retention_count = 10
+    minute = 15
+}
+
+resource "softlayer_storage_snapshot_schedule" "weekly" {
+    storage_id = "${softlayer_block_storage.bs_snapshot.id}"
+    type = "WEEKLY"
+    retention_count = 2
+    hour = 2
+    day_of_week = "SUNDAY"
+    enabled = false
+}
+`
@@ -0,0 +1,92 @@
+package softlayer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+)
+
+func TestAccSoftLayerStorageSnapshot_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSoftLayerStorageSnapshotDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerStorageSnapshotConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot.snapshot", "notes", "before upgrade"),
+					resource.TestCheckResourceAttrSet(
+						"softlayer_storage_snapshot.snapshot", "create_date"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerStorageSnapshotConfig_updated,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_storage_snapshot.snapshot", "notes", "after upgrade"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSoftLayerStorageSnapshotDestroy(s *terraform.State) error {
+	service := services.GetNetworkStorageService(testAccProvider.Meta().(*session.Session))
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "softlayer_storage_snapshot" {
+			continue
+		}
+
+		snapshotId, _ := strconv.Atoi(rs.Primary.ID)
+
+		// Try to find the snapshot
+		_, err := service.Id(snapshotId).GetObject()
+
+		if err == nil {
+			return fmt.Errorf("Snapshot %d still exists", snapshotId)
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckSoftLayerStorageSnapshotConfig_basic = `
+resource "softlayer_block_storage" "bs_snapshot" {
+    type = "Endurance"
+    datacenter = "dal06"
+    capacity = 20
+    iops = 2
+    snapshot_capacity = 10
+    os_type = "LINUX"
+}
+
+resource "softlayer_storage_snapshot" "snapshot" {
+    storage_id = "${softlayer_block_storage.bs_snapshot.id}"
+    notes = "before upgrade"
+}
+`
+
+const testAccCheckSoftLayerStorageSnapshotConfig_updated = `
+resource "softlayer_block_storage" "bs_snapshot" {
+    type = "Endurance"
+    datacenter = "dal06"
+    capacity = 20
+    iops = 2
+    snapshot_capacity = 10
+    os_type = "LINUX"
+}
+
+resource "softlayer_storage_snapshot" "snapshot" {
+    storage_id = "${softlayer_block_storage.bs_snapshot.id}"
+    notes = "after upgrade"
+}
+`
@@ -224,6 +224,20 @@ func resourceSoftLayerVirtualGuest() *schema.Resource {
 				ForceNew:      true,
 				ConflictsWith: []string{"os_reference_code"},
 			},
+
+			"security_group_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Set:      schema.HashInt,
+			},
+
+			"private_security_group_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Set:      schema.HashInt,
+			},
 		},
 	}
 }
@@ -448,9 +462,63 @@ func resourceSoftLayerVirtualGuestCreate(d *schema.ResourceData, meta interface{
 		}
 	}
 
+	err = updateVirtualGuestSecurityGroups(d, meta)
+	if err != nil {
+		return err
+	}
+
 	return resourceSoftLayerVirtualGuestRead(d, meta)
 }
 
+// updateVirtualGuestSecurityGroups attaches and detaches security groups on the primary and the
+// primary backend network components to match security_group_ids and private_security_group_ids.
+func updateVirtualGuestSecurityGroups(d *schema.ResourceData, meta interface{}) error {
+	if !d.HasChange("security_group_ids") && !d.HasChange("private_security_group_ids") {
+		return nil
+	}
+
+	sess := meta.(*session.Session)
+	service := services.GetVirtualGuestService(sess)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	result, err := service.Id(id).Mask("primaryNetworkComponent[id],primaryBackendNetworkComponent[id]").GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving virtual guest network components: %s", err)
+	}
+
+	if d.HasChange("security_group_ids") {
+		if result.PrimaryNetworkComponent == nil || result.PrimaryNetworkComponent.Id == nil {
+			return fmt.Errorf("Couldn't attach security groups: virtual guest %d has no public network component", id)
+		}
+
+		oldIds, newIds := d.GetChange("security_group_ids")
+		err = updateNetworkComponentSecurityGroups(
+			sess, *result.PrimaryNetworkComponent.Id, oldIds.(*schema.Set), newIds.(*schema.Set))
+		if err != nil {
+			return fmt.Errorf("Couldn't update security groups for virtual guest: %s", err)
+		}
+	}
+
+	if d.HasChange("private_security_group_ids") {
+		if result.PrimaryBackendNetworkComponent == nil || result.PrimaryBackendNetworkComponent.Id == nil {
+			return fmt.Errorf("Couldn't attach private security groups: virtual guest %d has no private network component", id)
+		}
+
+		oldIds, newIds := d.GetChange("private_security_group_ids")
+		err = updateNetworkComponentSecurityGroups(
+			sess, *result.PrimaryBackendNetworkComponent.Id, oldIds.(*schema.Set), newIds.(*schema.Set))
+		if err != nil {
+			return fmt.Errorf("Couldn't update private security groups for virtual guest: %s", err)
+		}
+	}
+
+	return nil
+}
+
 func resourceSoftLayerVirtualGuestRead(d *schema.ResourceData, meta interface{}) error {
 	service := services.GetVirtualGuestService(meta.(*session.Session))
 
@@ -520,6 +588,28 @@ func resourceSoftLayerVirtualGuestRead(d *schema.ResourceData, meta interface{})
 	resultBackendSubnet := result.PrimaryBackendNetworkComponent.PrimaryIpAddressRecord.Subnet
 	d.Set("back_end_subnet", *resultBackendSubnet.NetworkIdentifier+"/"+strconv.Itoa(*resultBackendSubnet.Cidr))
 
+	// Security groups are only looked up for guests which use them, as not every account may retrieve
+	// security group bindings.
+	if _, ok := d.GetOk("security_group_ids"); ok &&
+		result.PrimaryNetworkComponent != nil && result.PrimaryNetworkComponent.Id != nil {
+		securityGroupIds, err := getNetworkComponentSecurityGroupIds(
+			meta.(*session.Session), *result.PrimaryNetworkComponent.Id)
+		if err != nil {
+			return fmt.Errorf("Error retrieving virtual guest security groups: %s", err)
+		}
+		d.Set("security_group_ids", securityGroupIds)
+	}
+
+	if _, ok := d.GetOk("private_security_group_ids"); ok &&
+		result.PrimaryBackendNetworkComponent != nil && result.PrimaryBackendNetworkComponent.Id != nil {
+		securityGroupIds, err := getNetworkComponentSecurityGroupIds(
+			meta.(*session.Session), *result.PrimaryBackendNetworkComponent.Id)
+		if err != nil {
+			return fmt.Errorf("Error retrieving virtual guest security groups: %s", err)
+		}
+		d.Set("private_security_group_ids", securityGroupIds)
+	}
+
 	userData := result.UserData
 	if userData != nil && len(userData) > 0 {
 		data, err := base64.StdEncoding.DecodeString(*userData[0].Value)
@@ -570,6 +660,11 @@ func resourceSoftLayerVirtualGuestUpdate(d *schema.ResourceData, meta interface{
 		}
 	}
 
+	err = updateVirtualGuestSecurityGroups(d, meta)
+	if err != nil {
+		return err
+	}
+
 	// Upgrade "cpu", "ram" and "nic_speed" if provided and changed
 	upgradeOptions := map[string]float64{}
 	if d.HasChange("cpu") {
@@ -23,8 +23,16 @@ const (
 	AdditionalServicesPackageType            = "ADDITIONAL_SERVICES"
 	AdditionalServicesNetworkVlanPackageType = "ADDITIONAL_SERVICES_NETWORK_VLAN"
 
+	AdditionalServicesPortableIpAddressesPackageType = "ADDITIONAL_SERVICES_PORTABLE_IP_ADDRESSES"
+
 	VlanMask = "id,name,primaryRouter[datacenter[name]],primaryRouter[hostname],vlanNumber," +
-		"billingItem[recurringFee],guestNetworkComponentCount,subnets[networkIdentifier,cidr,subnetType]"
+		"billingItem[recurringFee],guestNetworkComponentCount," +
+		"subnets[id,networkIdentifier,cidr,subnetType,version,billingItem[id]],primarySubnet[id,cidr]"
+
+	VlanPackageItemMask = "id,capacity,description,units,keyName," +
+		"prices[id,locationGroupId,categories[id,name,categoryCode]]"
+
+	Ipv6SubnetSize = 64
 )
 
 func resourceSoftLayerVlan() *schema.Resource {
@@ -61,8 +69,22 @@ func resourceSoftLayerVlan() *schema.Resource {
 			},
 			"primary_subnet_size": &schema.Schema{
 				Type:     schema.TypeInt,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				ForceNew: true,
+				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
+					size := v.(int)
+					if size < 0 || size&(size-1) != 0 {
+						errors = append(errors, fmt.Errorf(
+							"Invalid primary_subnet_size: %d should be 0 or a power of 2", size))
+					}
+					return
+				},
+			},
+			"ipv6_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 			},
 			"name": &schema.Schema{
 				Type:     schema.TypeString,
@@ -117,6 +139,10 @@ func resourceSoftLayerVlanCreate(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("Error creating vlan: mismatch between vlan_type '%s' and primary_router_hostname '%s'", vlanType, router)
 	}
 
+	if vlanType == "PRIVATE" && d.Get("ipv6_enabled").(bool) {
+		return fmt.Errorf("Error creating vlan: IPv6 subnets are only available on PUBLIC vlans")
+	}
+
 	// Find price items with AdditionalServicesNetworkVlan
 	productOrderContainer, err := buildVlanProductOrderContainer(d, sess, AdditionalServicesNetworkVlanPackageType)
 	if err != nil {
@@ -136,6 +162,9 @@ func resourceSoftLayerVlanCreate(d *schema.ResourceData, meta interface{}) error
 	}
 
 	vlan, err := findVlanByOrderId(sess, *receipt.OrderId)
+	if err != nil {
+		return fmt.Errorf("Error finding created vlan: %s", err)
+	}
 
 	if len(name) > 0 {
 		_, err = services.GetNetworkVlanService(sess).
@@ -189,20 +218,26 @@ func resourceSoftLayerVlanRead(d *schema.ResourceData, meta interface{}) error {
 
 	// Subnets
 	subnets := make([]map[string]interface{}, 0)
+	ipv6Enabled := false
 
 	for _, elem := range vlan.Subnets {
 		subnet := make(map[string]interface{})
 		subnet["subnet"] = fmt.Sprintf("%s/%s", *elem.NetworkIdentifier, strconv.Itoa(*elem.Cidr))
 		subnet["subnet_type"] = *elem.SubnetType
 		subnets = append(subnets, subnet)
+
+		if sl.Get(elem.Version, 4).(int) == 6 {
+			ipv6Enabled = true
+		}
 	}
 	d.Set("subnets", subnets)
 
-	if vlan.Subnets != nil && len(vlan.Subnets) > 0 {
-		d.Set("primary_subnet_size", 1<<(uint)(32-*vlan.Subnets[0].Cidr))
+	if vlan.PrimarySubnet != nil && vlan.PrimarySubnet.Cidr != nil {
+		d.Set("primary_subnet_size", 1<<(uint)(32-*vlan.PrimarySubnet.Cidr))
 	} else {
 		d.Set("primary_subnet_size", 0)
 	}
+	d.Set("ipv6_enabled", ipv6Enabled)
 
 	return nil
 }
@@ -216,17 +251,35 @@ func resourceSoftLayerVlanUpdate(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("Not a valid vlan ID, must be an integer: %s", err)
 	}
 
-	opts := datatypes.Network_Vlan{}
+	// The changes are validated before any of them is made, so a failed update doesn't leave the vlan half updated.
+	if d.HasChange("ipv6_enabled") && d.Get("ipv6_enabled").(bool) && d.Get("type").(string) == "PRIVATE" {
+		return fmt.Errorf("Error updating vlan: IPv6 subnets are only available on PUBLIC vlans")
+	}
 
 	if d.HasChange("name") {
-		opts.Name = sl.String(d.Get("name").(string))
+		opts := datatypes.Network_Vlan{
+			Name: sl.String(d.Get("name").(string)),
+		}
+
+		_, err = service.Id(vlanId).EditObject(&opts)
+		if err != nil {
+			return fmt.Errorf("Error updating vlan: %s", err)
+		}
 	}
 
-	_, err = service.Id(vlanId).EditObject(&opts)
+	if d.HasChange("ipv6_enabled") {
+		if d.Get("ipv6_enabled").(bool) {
+			subnetKeyname := getVlanIpv6SubnetItemKeyName(true)
+			err = orderVlanSubnet(d, sess, vlanId, subnetKeyname)
+		} else {
+			err = cancelVlanIpv6Subnets(sess, vlanId)
+		}
 
-	if err != nil {
-		return fmt.Errorf("Error updating vlan: %s", err)
+		if err != nil {
+			return fmt.Errorf("Error updating vlan: %s", err)
+		}
 	}
+
 	return resourceSoftLayerVlanRead(d, meta)
 }
 
@@ -330,11 +383,16 @@ func buildVlanProductOrderContainer(d *schema.ResourceData, sess *session.Sessio
 			fmt.Errorf("datacenter name is empty.")
 	}
 
-	dc, err := location.GetDatacenterByName(sess, datacenter, "id")
+	dc, err := location.GetDatacenterByName(sess, datacenter, "id,priceGroups[id]")
 	if err != nil {
 		return &datatypes.Container_Product_Order_Network_Vlan{}, err
 	}
 
+	if dc.Id == nil {
+		return &datatypes.Container_Product_Order_Network_Vlan{},
+			fmt.Errorf("No datacenter found with name %s", datacenter)
+	}
+
 	// 1. Get a package
 	pkg, err := product.GetPackageByType(sess, packageType)
 	if err != nil {
@@ -342,50 +400,34 @@ func buildVlanProductOrderContainer(d *schema.ResourceData, sess *session.Sessio
 	}
 
 	// 2. Get all prices for the package
-	productItems, err := product.GetPackageProducts(sess, *pkg.Id)
+	productItems, err := product.GetPackageProducts(sess, *pkg.Id, VlanPackageItemMask)
 	if err != nil {
 		return &datatypes.Container_Product_Order_Network_Vlan{}, err
 	}
 
-	// 3. Find vlan and subnet prices
-	vlanKeyname := vlanType + "_NETWORK_VLAN"
-	subnetKeyname := strconv.Itoa(d.Get("primary_subnet_size").(int)) + "_STATIC_PUBLIC_IP_ADDRESSES"
+	// 3. Find vlan and subnet prices. The primary subnet is optional.
+	keynames := []string{vlanType + "_NETWORK_VLAN"}
 
-	// 4. Select items with a matching keyname
-	vlanItems := []datatypes.Product_Item{}
-	subnetItems := []datatypes.Product_Item{}
-	for _, item := range productItems {
-		if *item.KeyName == vlanKeyname {
-			vlanItems = append(vlanItems, item)
-		}
-		if strings.Contains(*item.KeyName, subnetKeyname) {
-			subnetItems = append(subnetItems, item)
-		}
+	if subnetSize := d.Get("primary_subnet_size").(int); subnetSize > 0 {
+		keynames = append(keynames, getVlanSubnetItemKeyName(vlanType, subnetSize))
 	}
 
-	if len(vlanItems) == 0 {
-		return &datatypes.Container_Product_Order_Network_Vlan{},
-			fmt.Errorf("No product items matching %s could be found", vlanKeyname)
+	if d.Get("ipv6_enabled").(bool) {
+		keynames = append(keynames, getVlanIpv6SubnetItemKeyName(false))
 	}
 
-	if len(subnetItems) == 0 {
-		return &datatypes.Container_Product_Order_Network_Vlan{},
-			fmt.Errorf("No product items matching %s could be found", subnetKeyname)
+	// 4. Select a price of each item which is valid in the datacenter
+	prices, err := selectVlanItemPrices(productItems, keynames, dc)
+	if err != nil {
+		return &datatypes.Container_Product_Order_Network_Vlan{}, err
 	}
 
 	productOrderContainer := datatypes.Container_Product_Order_Network_Vlan{
 		Container_Product_Order: datatypes.Container_Product_Order{
 			PackageId: pkg.Id,
 			Location:  sl.String(strconv.Itoa(*dc.Id)),
-			Prices: []datatypes.Product_Item_Price{
-				{
-					Id: vlanItems[0].Prices[0].Id,
-				},
-				{
-					Id: subnetItems[0].Prices[0].Id,
-				},
-			},
-			Quantity: sl.Int(1),
+			Prices:    prices,
+			Quantity:  sl.Int(1),
 		},
 	}
 
@@ -400,3 +442,183 @@ func buildVlanProductOrderContainer(d *schema.ResourceData, sess *session.Sessio
 
 	return &productOrderContainer, nil
 }
+
+// getVlanSubnetItemKeyName returns the product item key name of the primary IPv4 subnet of a vlan.
+// Subnets ordered together with a vlan are static for public vlans.
+func getVlanSubnetItemKeyName(vlanType string, size int) string {
+	if vlanType == "PRIVATE" {
+		return fmt.Sprintf("%d_PORTABLE_PRIVATE_IP_ADDRESSES", size)
+	}
+
+	return fmt.Sprintf("%d_STATIC_PUBLIC_IP_ADDRESSES", size)
+}
+
+func getVlanIpv6SubnetItemKeyName(portable bool) string {
+	if portable {
+		return fmt.Sprintf("%d_BLOCK_PORTABLE_PUBLIC_IPV6_ADDRESSES", Ipv6SubnetSize)
+	}
+
+	return fmt.Sprintf("%d_BLOCK_STATIC_PUBLIC_IPV6_ADDRESSES", Ipv6SubnetSize)
+}
+
+// selectVlanItemPrices returns one price for each of the keynames. A price which belongs to one of
+// the datacenter's price groups is preferred over the standard price, which has no location group.
+func selectVlanItemPrices(productItems []datatypes.Product_Item, keynames []string, dc datatypes.Location_Datacenter) (
+	[]datatypes.Product_Item_Price, error) {
+	priceGroups := make([]int, 0, len(dc.PriceGroups))
+	for _, group := range dc.PriceGroups {
+		priceGroups = append(priceGroups, *group.Id)
+	}
+
+	prices := make([]datatypes.Product_Item_Price, 0, len(keynames))
+	for _, keyname := range keynames {
+		var selected *datatypes.Product_Item_Price
+
+		for _, item := range productItems {
+			if item.KeyName == nil || *item.KeyName != keyname {
+				continue
+			}
+
+			for i, price := range item.Prices {
+				if price.LocationGroupId == nil {
+					if selected == nil {
+						selected = &item.Prices[i]
+					}
+				} else if contains(priceGroups, *price.LocationGroupId) {
+					selected = &item.Prices[i]
+					break
+				}
+			}
+		}
+
+		if selected == nil {
+			return nil, fmt.Errorf("No product items matching %s could be found", keyname)
+		}
+
+		prices = append(prices, datatypes.Product_Item_Price{Id: selected.Id})
+	}
+
+	return prices, nil
+}
+
+// orderVlanSubnet orders a portable subnet which is routed to the vlan and waits for the subnet to
+// be provisioned.
+func orderVlanSubnet(d *schema.ResourceData, sess *session.Session, vlanId int, keyname string) error {
+	dc, err := location.GetDatacenterByName(sess, d.Get("datacenter").(string), "id,priceGroups[id]")
+	if err != nil {
+		return err
+	}
+
+	if dc.Id == nil {
+		return fmt.Errorf("No datacenter found with name %s", d.Get("datacenter").(string))
+	}
+
+	var prices []datatypes.Product_Item_Price
+	var pkg datatypes.Product_Package
+	for _, packageType := range []string{AdditionalServicesPortableIpAddressesPackageType, AdditionalServicesPackageType} {
+		pkg, err = product.GetPackageByType(sess, packageType)
+		if err != nil {
+			continue
+		}
+
+		var productItems []datatypes.Product_Item
+		productItems, err = product.GetPackageProducts(sess, *pkg.Id, VlanPackageItemMask)
+		if err != nil {
+			continue
+		}
+
+		prices, err = selectVlanItemPrices(productItems, []string{keyname}, dc)
+		if err == nil {
+			break
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	productOrderContainer := datatypes.Container_Product_Order_Network_Subnet{
+		Container_Product_Order: datatypes.Container_Product_Order{
+			PackageId: pkg.Id,
+			Location:  sl.String(strconv.Itoa(*dc.Id)),
+			Prices:    prices,
+			Quantity:  sl.Int(1),
+		},
+		EndPointVlanId: sl.Int(vlanId),
+	}
+
+	log.Printf("[INFO] Ordering subnet %s for vlan %d", keyname, vlanId)
+
+	receipt, err := services.GetProductOrderService(sess).
+		PlaceOrder(&productOrderContainer, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error ordering subnet %s: %s", keyname, err)
+	}
+
+	_, err = findSubnetByOrderId(sess, *receipt.OrderId)
+	return err
+}
+
+func findSubnetByOrderId(sess *session.Session, orderId int) (datatypes.Network_Subnet, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"complete"},
+		Refresh: func() (interface{}, string, error) {
+			subnets, err := services.GetAccountService(sess).
+				Filter(filter.Path("subnets.billingItem.orderItem.order.id").
+					Eq(strconv.Itoa(orderId)).Build()).
+				Mask("id").
+				GetSubnets()
+			if err != nil {
+				return datatypes.Network_Subnet{}, "", err
+			}
+
+			if len(subnets) == 1 {
+				return subnets[0], "complete", nil
+			} else if len(subnets) == 0 {
+				return nil, "pending", nil
+			} else {
+				return nil, "", fmt.Errorf("Expected one subnet, found %d", len(subnets))
+			}
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	pendingResult, err := stateConf.WaitForState()
+
+	if err != nil {
+		return datatypes.Network_Subnet{}, err
+	}
+
+	var result, ok = pendingResult.(datatypes.Network_Subnet)
+
+	if ok {
+		return result, nil
+	}
+
+	return datatypes.Network_Subnet{},
+		fmt.Errorf("Cannot find subnet with order id '%d'", orderId)
+}
+
+// cancelVlanIpv6Subnets cancels the billing items of the IPv6 subnets on the vlan.
+func cancelVlanIpv6Subnets(sess *session.Session, vlanId int) error {
+	vlan, err := services.GetNetworkVlanService(sess).Id(vlanId).Mask(VlanMask).GetObject()
+	if err != nil {
+		return err
+	}
+
+	for _, subnet := range vlan.Subnets {
+		if sl.Get(subnet.Version, 4).(int) != 6 || subnet.BillingItem == nil || subnet.BillingItem.Id == nil {
+			continue
+		}
+
+		_, err = services.GetBillingItemService(sess).Id(*subnet.BillingItem.Id).CancelService()
+		if err != nil {
+			return fmt.Errorf("Error cancelling IPv6 subnet %d: %s", *subnet.Id, err)
+		}
+	}
+
+	return nil
+}
@@ -40,6 +40,54 @@ func TestAccSoftLayerVlan_Basic(t *testing.T) {
 	})
 }
 
+func TestAccSoftLayerVlan_PrivateWithoutSubnet(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerVlanConfig_private,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_vlan.test_private_vlan", "type", "PRIVATE"),
+					resource.TestCheckResourceAttr(
+						"softlayer_vlan.test_private_vlan", "primary_subnet_size", "0"),
+					resource.TestCheckResourceAttr(
+						"softlayer_vlan.test_private_vlan", "ipv6_enabled", "false"),
+				),
+			},
+
+			resource.TestStep{
+				Config: testAccCheckSoftLayerVlanConfig_private_subnet_added,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_vlan.test_private_vlan", "primary_subnet_size", "16"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSoftLayerVlan_Ipv6(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckSoftLayerVlanConfig_ipv6,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"softlayer_vlan.test_ipv6_vlan", "primary_subnet_size", "8"),
+					resource.TestCheckResourceAttr(
+						"softlayer_vlan.test_ipv6_vlan", "ipv6_enabled", "true"),
+				),
+			},
+		},
+	})
+}
+
 const testAccCheckSoftLayerVlanConfig_basic = `
 resource "softlayer_vlan" "test_vlan" {
    name = "test_vlan"
@@ -57,3 +105,27 @@ resource "softlayer_vlan" "test_vlan" {
    primary_subnet_size = 8
    primary_router_hostname = "fcr01a.lon02"
 }`
+
+const testAccCheckSoftLayerVlanConfig_private = `
+resource "softlayer_vlan" "test_private_vlan" {
+   name = "test_private_vlan"
+   datacenter = "lon02"
+   type = "PRIVATE"
+}`
+
+const testAccCheckSoftLayerVlanConfig_private_subnet_added = `
+resource "softlayer_vlan" "test_private_vlan" {
+   name = "test_private_vlan"
+   datacenter = "lon02"
+   type = "PRIVATE"
+   primary_subnet_size = 16
+}`
+
+const testAccCheckSoftLayerVlanConfig_ipv6 = `
+resource "softlayer_vlan" "test_ipv6_vlan" {
+   name = "test_ipv6_vlan"
+   datacenter = "lon02"
+   type = "PUBLIC"
+   primary_subnet_size = 8
+   ipv6_enabled = true
+}`